@@ -93,6 +93,8 @@ const (
 	DefaultSetSecurityContextReadOnlyRootFilesystem = false
 	// DefaultCoschedule is the default value for coschedule
 	DefaultCoschedule = CoscheduleWorkspaces
+	// DefaultEnableConcurrentValidation is the default value for "enable-concurrent-validation".
+	DefaultEnableConcurrentValidation = false
 	// KeepPodOnCancel is the flag used to enable cancelling a pod using the entrypoint, and keep pod on cancel
 	KeepPodOnCancel = "keep-pod-on-cancel"
 	// EnableCELInWhenExpression is the flag to enabled CEL in WhenExpression
@@ -130,6 +132,7 @@ const (
 	setSecurityContextKey                       = "set-security-context"
 	setSecurityContextReadOnlyRootFilesystemKey = "set-security-context-read-only-root-filesystem"
 	coscheduleKey                               = "coschedule"
+	enableConcurrentValidationKey               = "enable-concurrent-validation"
 )
 
 // DefaultFeatureFlags holds all the default configurations for the feature flags configmap.
@@ -204,6 +207,8 @@ type FeatureFlags struct {
 	DisableInlineSpec           string `json:"disableInlineSpec,omitempty"`
 	EnableConciseResolverSyntax bool   `json:"enableConciseResolverSyntax,omitempty"`
 	EnableKubernetesSidecar     bool   `json:"enableKubernetesSidecar,omitempty"`
+	// EnableConcurrentValidation runs the independent validations of a TaskSpec concurrently
+	EnableConcurrentValidation bool `json:"enableConcurrentValidation,omitempty"`
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -289,6 +294,9 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setCoschedule(cfgMap, DefaultCoschedule, &tc.Coschedule); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableConcurrentValidationKey, DefaultEnableConcurrentValidation, &tc.EnableConcurrentValidation); err != nil {
+		return nil, err
+	}
 	if err := setPerFeatureFlag(EnableCELInWhenExpression, DefaultEnableCELInWhenExpression, &tc.EnableCELInWhenExpression); err != nil {
 		return nil, err
 	}
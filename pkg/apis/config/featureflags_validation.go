@@ -21,14 +21,55 @@ package config
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"knative.dev/pkg/apis"
 )
 
+// apiFieldsRecorderKey is used as the key for associating an APIFieldsRecorder with the context.
+type apiFieldsRecorderKey struct{}
+
+// apiFieldsOrder ranks the "enable-api-fields" versions from the most to the least stable.
+var apiFieldsOrder = map[string]int{StableAPIFields: 0, BetaAPIFields: 1, AlphaAPIFields: 2}
+
+// APIFieldsRecorder records the least stable "enable-api-fields" version required by the
+// features checked with ValidateEnabledAPIFields.
+type APIFieldsRecorder struct {
+	mu       sync.Mutex
+	required string
+}
+
+// WithAPIFieldsRecorder returns a context in which ValidateEnabledAPIFields records the version
+// required by each feature it checks in r.
+func WithAPIFieldsRecorder(ctx context.Context, r *APIFieldsRecorder) context.Context {
+	return context.WithValue(ctx, apiFieldsRecorderKey{}, r)
+}
+
+// Required returns the least stable version recorded, or "stable" when no gated feature was checked.
+func (r *APIFieldsRecorder) Required() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.required == "" {
+		return StableAPIFields
+	}
+	return r.required
+}
+
+func (r *APIFieldsRecorder) record(version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if order, ok := apiFieldsOrder[version]; ok && order > apiFieldsOrder[r.required] {
+		r.required = version
+	}
+}
+
 // ValidateEnabledAPIFields checks that the enable-api-fields feature gate is set
 // to a version at most as stable as wantVersion, if not, returns an error stating which feature
 // is dependent on the version and what the current version actually is.
 func ValidateEnabledAPIFields(ctx context.Context, featureName string, wantVersion string) *apis.FieldError {
+	if r, ok := ctx.Value(apiFieldsRecorderKey{}).(*APIFieldsRecorder); ok {
+		r.record(wantVersion)
+	}
 	currentVersion := FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields
 	var errs *apis.FieldError
 	message := `%s requires "enable-api-fields" feature gate to be %s but it is %q`
@@ -113,3 +113,38 @@ func TestValidateEnabledAPIFieldsError(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateEnabledAPIFieldsRecordsRequiredVersion(t *testing.T) {
+	tcs := []struct {
+		name         string
+		wantVersions []string
+		want         string
+	}{{
+		name: "no gated feature",
+		want: "stable",
+	}, {
+		name:         "beta feature",
+		wantVersions: []string{"stable", "beta"},
+		want:         "beta",
+	}, {
+		name:         "alpha feature checked before a beta feature",
+		wantVersions: []string{"alpha", "beta"},
+		want:         "alpha",
+	}, {
+		name:         "invalid wantVersion",
+		wantVersions: []string{"foo"},
+		want:         "stable",
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &config.APIFieldsRecorder{}
+			ctx := config.WithAPIFieldsRecorder(t.Context(), r)
+			for _, v := range tc.wantVersions {
+				config.ValidateEnabledAPIFields(ctx, "test feature", v)
+			}
+			if got := r.Required(); got != tc.want {
+				t.Errorf("APIFieldsRecorder.Required() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	spireConfig := test.ConfigMapFromTestFile(t, "config-spire")
 	eventsConfig := test.ConfigMapFromTestFile(t, "config-events")
 	tracingConfig := test.ConfigMapFromTestFile(t, "config-tracing")
+	validationPolicyConfig := test.ConfigMapFromTestFile(t, "config-validation-policy")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
@@ -40,14 +41,16 @@ func TestStoreLoadWithContext(t *testing.T) {
 	expectedSpireConfig, _ := config.NewSpireConfigFromConfigMap(spireConfig)
 	expectedEventsConfig, _ := config.NewEventsFromConfigMap(eventsConfig)
 	expectedTracingConfig, _ := config.NewTracingFromConfigMap(tracingConfig)
+	expectedValidationPolicy, _ := config.NewValidationPolicyFromConfigMap(validationPolicyConfig)
 
 	expected := &config.Config{
-		Defaults:     expectedDefaults,
-		FeatureFlags: expectedFeatures,
-		Metrics:      metrics,
-		SpireConfig:  expectedSpireConfig,
-		Events:       expectedEventsConfig,
-		Tracing:      expectedTracingConfig,
+		Defaults:         expectedDefaults,
+		FeatureFlags:     expectedFeatures,
+		Metrics:          metrics,
+		SpireConfig:      expectedSpireConfig,
+		Events:           expectedEventsConfig,
+		Tracing:          expectedTracingConfig,
+		ValidationPolicy: expectedValidationPolicy,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -57,6 +60,7 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(spireConfig)
 	store.OnConfigChanged(eventsConfig)
 	store.OnConfigChanged(tracingConfig)
+	store.OnConfigChanged(validationPolicyConfig)
 
 	cfg := config.FromContext(store.ToContext(t.Context()))
 
@@ -67,12 +71,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 
 func TestStoreLoadWithContext_Empty(t *testing.T) {
 	want := &config.Config{
-		Defaults:     config.DefaultConfig.DeepCopy(),
-		FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
-		Metrics:      config.DefaultMetrics.DeepCopy(),
-		SpireConfig:  config.DefaultSpire.DeepCopy(),
-		Events:       config.DefaultEvents.DeepCopy(),
-		Tracing:      config.DefaultTracing.DeepCopy(),
+		Defaults:         config.DefaultConfig.DeepCopy(),
+		FeatureFlags:     config.DefaultFeatureFlags.DeepCopy(),
+		Metrics:          config.DefaultMetrics.DeepCopy(),
+		SpireConfig:      config.DefaultSpire.DeepCopy(),
+		Events:           config.DefaultEvents.DeepCopy(),
+		Tracing:          config.DefaultTracing.DeepCopy(),
+		ValidationPolicy: config.DefaultValidationPolicy.DeepCopy(),
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
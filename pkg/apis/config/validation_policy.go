@@ -0,0 +1,287 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultRequireExplicitParamTypes is the default value for "require-explicit-param-types".
+	DefaultRequireExplicitParamTypes = false
+	// DefaultAllowedParamTypes is the default value for "allowed-param-types", which allows all param types.
+	DefaultAllowedParamTypes = ""
+	// DefaultMaxStepScriptSize is the default value for "max-step-script-size", which does not limit the script size.
+	DefaultMaxStepScriptSize = 0
+	// DefaultMaxTotalScriptSize is the default value for "max-total-script-size", which does not limit the script size.
+	DefaultMaxTotalScriptSize = 0
+	// DefaultWarnUnpinnedPlatformImages is the default value for "warn-unpinned-platform-images".
+	DefaultWarnUnpinnedPlatformImages = false
+	// DefaultDeniedStepCommands is the default value for "denied-step-commands", which denies no commands.
+	DefaultDeniedStepCommands = ""
+	// DefaultMaxResultCount is the default value for "max-result-count", which does not limit the number of results.
+	DefaultMaxResultCount = 0
+	// DefaultRequireStepNames is the default value for "require-step-names".
+	DefaultRequireStepNames = false
+	// DefaultAllowedImageRegistries is the default value for "allowed-image-registries", which allows all registries.
+	DefaultAllowedImageRegistries = ""
+	// DefaultMaxDescriptionLength is the default value for "max-description-length", which does not limit the description length.
+	DefaultMaxDescriptionLength = 0
+	// DefaultImagesWithoutShell is the default value for "images-without-shell", which lists no images.
+	DefaultImagesWithoutShell = ""
+	// DefaultRequireDescriptions is the default value for "require-descriptions".
+	DefaultRequireDescriptions = false
+	// DefaultRequireStepResourceLimits is the default value for "require-step-resource-limits".
+	DefaultRequireStepResourceLimits = false
+	// DefaultMaxWorkspaceCount is the default value for "max-workspace-count", which is well above the number
+	// of workspaces of common Tasks.
+	DefaultMaxWorkspaceCount = 64
+	// DefaultParamNamePattern is the default value for "param-name-pattern", which only applies the
+	// base format of param names.
+	DefaultParamNamePattern = ""
+	// DefaultDenyPrivilegeEscalation is the default value for "deny-privilege-escalation".
+	DefaultDenyPrivilegeEscalation = false
+
+	requireExplicitParamTypesKey  = "require-explicit-param-types"
+	allowedParamTypesKey          = "allowed-param-types"
+	maxStepScriptSizeKey          = "max-step-script-size"
+	maxTotalScriptSizeKey         = "max-total-script-size"
+	warnUnpinnedPlatformImagesKey = "warn-unpinned-platform-images"
+	deniedStepCommandsKey         = "denied-step-commands"
+	maxResultCountKey             = "max-result-count"
+	requireStepNamesKey           = "require-step-names"
+	allowedImageRegistriesKey     = "allowed-image-registries"
+	maxDescriptionLengthKey       = "max-description-length"
+	imagesWithoutShellKey         = "images-without-shell"
+	requireDescriptionsKey        = "require-descriptions"
+	requireStepResourceLimitsKey  = "require-step-resource-limits"
+	maxWorkspaceCountKey          = "max-workspace-count"
+	paramNamePatternKey           = "param-name-pattern"
+	denyPrivilegeEscalationKey    = "deny-privilege-escalation"
+)
+
+// DefaultValidationPolicy holds all the default configurations for the validation policy.
+var DefaultValidationPolicy, _ = NewValidationPolicyFromMap(map[string]string{})
+
+// ValidationPolicy holds the policies that cluster operators can enforce on Tasks on top of
+// the API validation. Unlike FeatureFlags, they do not gate API fields and are not recorded in
+// the provenance of TaskRuns and PipelineRuns.
+// +k8s:deepcopy-gen=true
+type ValidationPolicy struct {
+	// RequireExplicitParamTypes requires every param to declare its type instead of inferring it
+	RequireExplicitParamTypes bool
+	// AllowedParamTypes is a comma separated list of the param types that can be declared, empty allows all types
+	AllowedParamTypes string
+	// MaxStepScriptSize is the maximum size in bytes of a single step script, 0 disables the check
+	MaxStepScriptSize int
+	// MaxTotalScriptSize is the maximum combined size in bytes of the step scripts of a Task, 0 disables the check
+	MaxTotalScriptSize int
+	// WarnUnpinnedPlatformImages warns when a Task declaring a target platform uses images that are not pinned by digest
+	WarnUnpinnedPlatformImages bool
+	// DeniedStepCommands is a comma separated list of substrings that step and sidecar commands, args and scripts must not contain
+	DeniedStepCommands string
+	// MaxResultCount is the maximum number of results a Task can declare, 0 disables the check
+	MaxResultCount int
+	// RequireStepNames requires every step to declare a name instead of having one generated
+	RequireStepNames bool
+	// AllowedImageRegistries is a comma separated list of the registries that step and sidecar images can be pulled from, empty allows all registries
+	AllowedImageRegistries string
+	// MaxDescriptionLength is the maximum length in bytes of result and param descriptions, 0 disables the check
+	MaxDescriptionLength int
+	// ImagesWithoutShell is a comma separated list of images known not to have a shell to run step and sidecar scripts
+	ImagesWithoutShell string
+	// RequireDescriptions requires every result and param to declare a description
+	RequireDescriptions bool
+	// RequireStepResourceLimits requires every step to declare CPU and memory limits
+	RequireStepResourceLimits bool
+	// MaxWorkspaceCount is the maximum number of workspaces a Task can declare, 0 disables the check
+	MaxWorkspaceCount int
+	// ParamNamePattern is a regular expression that param names must fully match on top of the base format, empty disables the check
+	ParamNamePattern string
+	// DenyPrivilegeEscalation requires steps and sidecars not to set allowPrivilegeEscalation to true
+	DenyPrivilegeEscalation bool
+}
+
+// GetValidationPolicyConfigName returns the name of the configmap containing all
+// the validation policies.
+func GetValidationPolicyConfigName() string {
+	if e := os.Getenv("CONFIG_VALIDATION_POLICY_NAME"); e != "" {
+		return e
+	}
+	return "config-validation-policy"
+}
+
+// NewValidationPolicyFromMap returns a ValidationPolicy given a map corresponding to a ConfigMap
+func NewValidationPolicyFromMap(cfgMap map[string]string) (*ValidationPolicy, error) {
+	setBool := func(key string, defaultValue bool, field *bool) error {
+		value := defaultValue
+		if cfg, ok := cfgMap[key]; ok {
+			v, err := strconv.ParseBool(cfg)
+			if err != nil {
+				return fmt.Errorf("failed parsing validation policy %q: %w", key, err)
+			}
+			value = v
+		}
+		*field = value
+		return nil
+	}
+	// for any size or count limit, where 0 disables the limit
+	setLimit := func(key string, defaultValue int, field *int) error {
+		value := defaultValue
+		if cfg, ok := cfgMap[key]; ok {
+			v, err := strconv.Atoi(cfg)
+			if err != nil {
+				return fmt.Errorf("failed parsing validation policy %q: %w", key, err)
+			}
+			if v < 0 {
+				return fmt.Errorf("invalid value for validation policy %q: %q", key, cfg)
+			}
+			value = v
+		}
+		*field = value
+		return nil
+	}
+	// for any string with no extra validation
+	setString := func(key string, defaultValue string, field *string) {
+		if cfg, ok := cfgMap[key]; ok {
+			*field = cfg
+		} else {
+			*field = defaultValue
+		}
+	}
+	// for any comma separated list, dropping the whitespace around its items
+	setList := func(key string, defaultValue string, field *string) {
+		value := defaultValue
+		if cfg, ok := cfgMap[key]; ok {
+			value = cfg
+		}
+		*field = strings.ReplaceAll(value, " ", "")
+	}
+
+	vp := ValidationPolicy{}
+	if err := setBool(requireExplicitParamTypesKey, DefaultRequireExplicitParamTypes, &vp.RequireExplicitParamTypes); err != nil {
+		return nil, err
+	}
+	setList(allowedParamTypesKey, DefaultAllowedParamTypes, &vp.AllowedParamTypes)
+	if err := setLimit(maxStepScriptSizeKey, DefaultMaxStepScriptSize, &vp.MaxStepScriptSize); err != nil {
+		return nil, err
+	}
+	if err := setLimit(maxTotalScriptSizeKey, DefaultMaxTotalScriptSize, &vp.MaxTotalScriptSize); err != nil {
+		return nil, err
+	}
+	if err := setBool(warnUnpinnedPlatformImagesKey, DefaultWarnUnpinnedPlatformImages, &vp.WarnUnpinnedPlatformImages); err != nil {
+		return nil, err
+	}
+	// Spaces are kept since they are part of the denied substrings.
+	setString(deniedStepCommandsKey, DefaultDeniedStepCommands, &vp.DeniedStepCommands)
+	if err := setLimit(maxResultCountKey, DefaultMaxResultCount, &vp.MaxResultCount); err != nil {
+		return nil, err
+	}
+	if err := setBool(requireStepNamesKey, DefaultRequireStepNames, &vp.RequireStepNames); err != nil {
+		return nil, err
+	}
+	setList(allowedImageRegistriesKey, DefaultAllowedImageRegistries, &vp.AllowedImageRegistries)
+	// Registry hosts are case insensitive.
+	vp.AllowedImageRegistries = strings.ToLower(vp.AllowedImageRegistries)
+	if err := setLimit(maxDescriptionLengthKey, DefaultMaxDescriptionLength, &vp.MaxDescriptionLength); err != nil {
+		return nil, err
+	}
+	setList(imagesWithoutShellKey, DefaultImagesWithoutShell, &vp.ImagesWithoutShell)
+	if err := setBool(requireDescriptionsKey, DefaultRequireDescriptions, &vp.RequireDescriptions); err != nil {
+		return nil, err
+	}
+	if err := setBool(requireStepResourceLimitsKey, DefaultRequireStepResourceLimits, &vp.RequireStepResourceLimits); err != nil {
+		return nil, err
+	}
+	if err := setLimit(maxWorkspaceCountKey, DefaultMaxWorkspaceCount, &vp.MaxWorkspaceCount); err != nil {
+		return nil, err
+	}
+	setString(paramNamePatternKey, DefaultParamNamePattern, &vp.ParamNamePattern)
+	if _, err := regexp.Compile(vp.ParamNamePattern); err != nil {
+		return nil, fmt.Errorf("failed parsing validation policy %q: %w", paramNamePatternKey, err)
+	}
+	if err := setBool(denyPrivilegeEscalationKey, DefaultDenyPrivilegeEscalation, &vp.DenyPrivilegeEscalation); err != nil {
+		return nil, err
+	}
+	return &vp, nil
+}
+
+// NewValidationPolicyFromConfigMap returns a ValidationPolicy for the given configmap
+func NewValidationPolicyFromConfigMap(config *corev1.ConfigMap) (*ValidationPolicy, error) {
+	return NewValidationPolicyFromMap(config.Data)
+}
+
+// GetDeniedStepCommands returns the substrings listed in "denied-step-commands", with surrounding
+// whitespace removed. Empty entries are ignored.
+func (vp *ValidationPolicy) GetDeniedStepCommands() []string {
+	var denied []string
+	for _, d := range strings.Split(vp.DeniedStepCommands, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			denied = append(denied, d)
+		}
+	}
+	return denied
+}
+
+// GetAllowedImageRegistries returns the registries listed in "allowed-image-registries".
+// Empty entries are ignored.
+func (vp *ValidationPolicy) GetAllowedImageRegistries() []string {
+	var allowed []string
+	for _, r := range strings.Split(vp.AllowedImageRegistries, ",") {
+		if r != "" {
+			allowed = append(allowed, r)
+		}
+	}
+	return allowed
+}
+
+// GetImagesWithoutShell returns the images listed in "images-without-shell".
+// Empty entries are ignored.
+func (vp *ValidationPolicy) GetImagesWithoutShell() []string {
+	var images []string
+	for _, i := range strings.Split(vp.ImagesWithoutShell, ",") {
+		if i != "" {
+			images = append(images, i)
+		}
+	}
+	return images
+}
+
+// GetParamNamePattern returns the regular expression of "param-name-pattern", anchored so that it
+// matches whole names, or nil when the policy is not set.
+func (vp *ValidationPolicy) GetParamNamePattern() (*regexp.Regexp, error) {
+	if vp.ParamNamePattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + vp.ParamNamePattern + ")$")
+}
+
+// ValidationPolicyFromContextOrDefaults returns the ValidationPolicy of the Config attached to the
+// provided context, or the default ValidationPolicy when none is attached.
+func ValidationPolicyFromContextOrDefaults(ctx context.Context) *ValidationPolicy {
+	if cfg := FromContext(ctx); cfg != nil && cfg.ValidationPolicy != nil {
+		return cfg.ValidationPolicy
+	}
+	return DefaultValidationPolicy.DeepCopy()
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewValidationPolicyFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		want     *config.ValidationPolicy
+		fileName string
+	}{{
+		name:     "empty",
+		want:     &config.ValidationPolicy{MaxWorkspaceCount: config.DefaultMaxWorkspaceCount},
+		fileName: "config-validation-policy-empty",
+	}, {
+		name: "all policies set",
+		want: &config.ValidationPolicy{
+			RequireExplicitParamTypes:  true,
+			AllowedParamTypes:          "string,array",
+			MaxStepScriptSize:          1024,
+			MaxTotalScriptSize:         2048,
+			WarnUnpinnedPlatformImages: true,
+			DeniedStepCommands:         "curl | sh, wget | sh",
+			MaxResultCount:             10,
+			RequireStepNames:           true,
+			AllowedImageRegistries:     "gcr.io,ghcr.io",
+			MaxDescriptionLength:       512,
+			ImagesWithoutShell:         "gcr.io/distroless/static,scratch",
+			RequireDescriptions:        true,
+			RequireStepResourceLimits:  true,
+			MaxWorkspaceCount:          16,
+			ParamNamePattern:           "[a-z][a-z0-9-]*",
+			DenyPrivilegeEscalation:    true,
+		},
+		fileName: "config-validation-policy",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewValidationPolicyFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewValidationPolicyFromConfigMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewValidationPolicyFromConfigMapErrors(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+		want     string
+	}{{
+		fileName: "config-validation-policy-invalid-boolean",
+		want:     `failed parsing validation policy "require-explicit-param-types": strconv.ParseBool: parsing "yes please": invalid syntax`,
+	}, {
+		fileName: "config-validation-policy-invalid-max-step-script-size",
+		want:     `invalid value for validation policy "max-step-script-size": "-1"`,
+	}, {
+		fileName: "config-validation-policy-invalid-param-name-pattern",
+		want:     "failed parsing validation policy \"param-name-pattern\": error parsing regexp: missing closing ]: `[a-z`",
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			_, err := config.NewValidationPolicyFromConfigMap(cm)
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if d := cmp.Diff(tc.want, err.Error()); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidationPolicyFromContextOrDefaults(t *testing.T) {
+	if d := cmp.Diff(config.DefaultValidationPolicy, config.ValidationPolicyFromContextOrDefaults(t.Context())); d != "" {
+		t.Errorf("ValidationPolicyFromContextOrDefaults() without config %s", diff.PrintWantGot(d))
+	}
+	ctx := config.ToContext(t.Context(), &config.Config{FeatureFlags: config.DefaultFeatureFlags.DeepCopy()})
+	if d := cmp.Diff(config.DefaultValidationPolicy, config.ValidationPolicyFromContextOrDefaults(ctx)); d != "" {
+		t.Errorf("ValidationPolicyFromContextOrDefaults() without policy %s", diff.PrintWantGot(d))
+	}
+	want := &config.ValidationPolicy{RequireExplicitParamTypes: true}
+	ctx = config.ToContext(t.Context(), &config.Config{ValidationPolicy: want})
+	if d := cmp.Diff(want, config.ValidationPolicyFromContextOrDefaults(ctx)); d != "" {
+		t.Errorf("ValidationPolicyFromContextOrDefaults() with policy %s", diff.PrintWantGot(d))
+	}
+}
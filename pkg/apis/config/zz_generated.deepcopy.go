@@ -134,3 +134,19 @@ func (in *Tracing) DeepCopy() *Tracing {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationPolicy) DeepCopyInto(out *ValidationPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationPolicy.
+func (in *ValidationPolicy) DeepCopy() *ValidationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,4 +19,12 @@ package pipeline
 const (
 	// TektonReservedAnnotationExpr is the expression we use to filter out reserved key in annotation
 	TektonReservedAnnotationExpr = "(chains.tekton.dev)/.*"
+
+	// ReservedStepNamePrefix is the prefix of the names generated for the steps which do not declare
+	// one, followed by the index of the step, e.g. "unnamed-0". See ReservedStepNameExpr.
+	ReservedStepNamePrefix = "unnamed-"
+
+	// ReservedStepNameExpr is the expression matching the names generated for the steps which do not
+	// declare one, so the names of the steps cannot match it.
+	ReservedStepNameExpr = "^" + ReservedStepNamePrefix + "[0-9]+$"
 )
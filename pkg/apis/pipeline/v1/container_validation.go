@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
@@ -111,6 +112,8 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	if s.Ref != nil {
 		errs = errs.Also(s.Ref.Validate(ctx))
+		// The params are passed to the referenced StepAction, which is resolved later on.
+		errs = errs.Also(ValidateParameters(ctx, s.Params).ViaField("params"))
 		if s.Image != "" {
 			errs = errs.Also(&apis.FieldError{
 				Message: "image cannot be used with Ref",
@@ -188,6 +191,14 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 				Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
 			})
 		}
+		// The generated names of unnamed steps would collide with the step name.
+		if reservedStepNameRegex.MatchString(s.Name) {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid value %q", s.Name),
+				Paths:   []string{"name"},
+				Details: fmt.Sprintf("Task step name cannot be %q followed by a number, which is reserved for the names generated for unnamed steps", pipeline.ReservedStepNamePrefix),
+			})
+		}
 	}
 
 	if s.Timeout != nil {
@@ -220,6 +231,8 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 		cleaned := strings.TrimSpace(s.Script)
 		if strings.HasPrefix(cleaned, "#!win") {
 			errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "windows script support", config.AlphaAPIFields).ViaField("script"))
+		} else if strings.HasPrefix(cleaned, "#!") {
+			errs = errs.Also(validateScriptShebang(cleaned).ViaField("script"))
 		}
 	}
 
@@ -227,13 +240,18 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// when the enable-api-fields feature gate is not "alpha".
 	if s.StdoutConfig != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stdout stream support", config.AlphaAPIFields).ViaField("stdoutconfig"))
+		errs = errs.Also(validateOutputCapturePath(s.StdoutConfig.Path).ViaField("stdoutconfig"))
 	}
 	// StderrConfig is an alpha feature and will fail validation if it's used in a task spec
 	// when the enable-api-fields feature gate is not "alpha".
 	if s.StderrConfig != nil {
 		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
+		errs = errs.Also(validateOutputCapturePath(s.StderrConfig.Path).ViaField("stderrconfig"))
 	}
 
+	errs = errs.Also(validateDeniedCommands(ctx, s.Command, s.Args, s.Script))
+	errs = errs.Also(validateScriptImageHasShell(ctx, s.Image, s.Script))
+
 	// Validate usage of step result reference.
 	// Referencing previous step's results are only allowed in `env`, `command` and `args`.
 	errs = errs.Also(validateStepResultReference(s))
@@ -244,6 +262,89 @@ func (s *Step) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// variableReferenceRegex matches variable references, e.g. $(params.foo)
+// reservedStepNameRegex matches the names generated for the steps which do not declare one, e.g. unnamed-0.
+var reservedStepNameRegex = regexp.MustCompile(pipeline.ReservedStepNameExpr)
+
+var variableReferenceRegex = regexp.MustCompile(`\$\([^)]*\)`)
+
+// validateDeniedCommands returns an error if the command, args or script contain any of the substrings
+// denied by the "denied-step-commands" policy. Variable references are resolved at runtime, so only the
+// statically known text around them is checked.
+func validateDeniedCommands(ctx context.Context, command, args []string, script string) (errs *apis.FieldError) {
+	denied := config.ValidationPolicyFromContextOrDefaults(ctx).GetDeniedStepCommands()
+	if len(denied) == 0 {
+		return nil
+	}
+	for i, c := range command {
+		errs = errs.Also(validateNotDenied(c, denied).ViaIndex(i).ViaField("command"))
+	}
+	for i, a := range args {
+		errs = errs.Also(validateNotDenied(a, denied).ViaIndex(i).ViaField("args"))
+	}
+	return errs.Also(validateNotDenied(script, denied).ViaField("script"))
+}
+
+// validateScriptImageHasShell returns a warning if a script runs on an image listed by the
+// "images-without-shell" policy, since the script cannot be run without a shell. An image listed
+// without a tag or digest matches all of its tags and digests. Templated images are not checked.
+func validateScriptImageHasShell(ctx context.Context, image, script string) *apis.FieldError {
+	if script == "" || image == "" || strings.Contains(image, "$(") {
+		return nil
+	}
+	repository, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+	for _, listed := range config.ValidationPolicyFromContextOrDefaults(ctx).GetImagesWithoutShell() {
+		if listed == image || listed == repository {
+			return apis.ErrGeneric(fmt.Sprintf("image %q has no shell according to the %q validation policy, so the script may not run", image, "images-without-shell"), "script").At(apis.WarningLevel)
+		}
+	}
+	return nil
+}
+
+func validateNotDenied(value string, denied []string) *apis.FieldError {
+	for _, fragment := range variableReferenceRegex.Split(value, -1) {
+		for _, d := range denied {
+			if strings.Contains(fragment, d) {
+				return apis.ErrGeneric(fmt.Sprintf("%q is denied by the %q validation policy", d, "denied-step-commands"), "")
+			}
+		}
+	}
+	return nil
+}
+
+// validateOutputCapturePath returns an error if a stdout or stderr capture path falls under the
+// directories reserved for results, since writing there directly would corrupt them. Results should
+// be captured through a $(results.<name>.path) or $(step.results.<name>.path) reference instead.
+func validateOutputCapturePath(p string) *apis.FieldError {
+	if p == "" || strings.Contains(p, "$(") {
+		return nil
+	}
+	cleaned := path.Clean(p)
+	for _, dir := range []string{pipeline.DefaultResultPath, pipeline.StepsDir} {
+		if cleaned == dir || strings.HasPrefix(cleaned, dir+"/") {
+			return apis.ErrGeneric(fmt.Sprintf("capture path %q cannot be under the reserved directory %q", p, dir), "path")
+		}
+	}
+	return nil
+}
+
+// validateScriptShebang returns a warning if the interpreter in the shebang line of
+// script is not an absolute path, since the kernel does not look it up in PATH.
+func validateScriptShebang(script string) *apis.FieldError {
+	shebang, _, _ := strings.Cut(strings.TrimPrefix(script, "#!"), "\n")
+	interpreter := ""
+	if fields := strings.Fields(shebang); len(fields) > 0 {
+		interpreter = fields[0]
+	}
+	if !strings.HasPrefix(interpreter, "/") {
+		return apis.ErrGeneric(fmt.Sprintf("script shebang interpreter %q is not an absolute path", interpreter), "").At(apis.WarningLevel)
+	}
+	return nil
+}
+
 // isParamRefs attempts to check if a specified string looks like it contains any parameter reference
 // This is useful to make sure the specified value looks like a Parameter Reference before performing any strict validation
 func isParamRefs(s string) bool {
@@ -385,5 +486,10 @@ func (sc *Sidecar) Validate(ctx context.Context) (errs *apis.FieldError) {
 			})
 		}
 	}
+	if p := path.Clean(sc.TerminationMessagePath); sc.TerminationMessagePath != "" && (p == "/tekton" || strings.HasPrefix(p, "/tekton/")) {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("terminationMessagePath cannot be under /tekton/, which is reserved for the files of Tekton (got %q)", sc.TerminationMessagePath), "terminationMessagePath"))
+	}
+	errs = errs.Also(validateDeniedCommands(ctx, sc.Command, sc.Args, sc.Script))
+	errs = errs.Also(validateScriptImageHasShell(ctx, sc.Image, sc.Script))
 	return errs
 }
@@ -200,6 +200,18 @@ func TestStepValidate(t *testing.T) {
 				#!/usr/bin/env  bash
 				hello $1`,
 		},
+	}, {
+		name: "valid step name starting with unnamed-",
+		Step: v1.Step{
+			Name:  "unnamed-build",
+			Image: "myimage",
+		},
+	}, {
+		name: "valid step name starting with step-",
+		Step: v1.Step{
+			Name:  "step-unpack",
+			Image: "myimage",
+		},
 	}, {
 		name: "valid step with volumeMount under /tekton/home",
 		Step: v1.Step{
@@ -243,6 +255,17 @@ func TestStepValidateError(t *testing.T) {
 			Paths:   []string{"name"},
 			Details: "Task step name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
 		},
+	}, {
+		name: "step name with reserved prefix",
+		Step: v1.Step{
+			Name:  "unnamed-0",
+			Image: "myimage",
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value "unnamed-0"`,
+			Paths:   []string{"name"},
+			Details: `Task step name cannot be "unnamed-" followed by a number, which is reserved for the names generated for unnamed steps`,
+		},
 	}, {
 		name: "step with script and command",
 		Step: v1.Step{
@@ -630,6 +653,40 @@ func TestStepValidateErrorWithStepActionRef(t *testing.T) {
 				Message: "results cannot be used with Ref",
 				Paths:   []string{"results"},
 			},
+		}, {
+			name: "Cannot use image and script with Ref",
+			Step: v1.Step{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Image:  "foo",
+				Script: "echo hi",
+			},
+			expectedError: *(&apis.FieldError{
+				Message: "image cannot be used with Ref",
+				Paths:   []string{"image"},
+			}).Also(&apis.FieldError{
+				Message: "script cannot be used with Ref",
+				Paths:   []string{"script"},
+			}),
+		}, {
+			name: "Cannot pass duplicate params to Ref",
+			Step: v1.Step{
+				Ref: &v1.Ref{
+					Name: "stepAction",
+				},
+				Params: v1.Params{{
+					Name:  "param",
+					Value: *v1.NewStructuredValues("foo"),
+				}, {
+					Name:  "param",
+					Value: *v1.NewStructuredValues("bar"),
+				}},
+			},
+			expectedError: apis.FieldError{
+				Message: "expected exactly one, got both",
+				Paths:   []string{"params[param].name"},
+			},
 		},
 	}
 	for _, st := range tests {
@@ -708,6 +765,221 @@ func TestStepOnError(t *testing.T) {
 	}
 }
 
+func TestStepScriptShebang(t *testing.T) {
+	tests := []struct {
+		name            string
+		step            v1.Step
+		expectedWarning *apis.FieldError
+	}{{
+		name: "absolute shebang",
+		step: v1.Step{
+			Image: "my-image",
+			Script: `
+				#!/usr/bin/env bash
+				echo hello`,
+		},
+	}, {
+		name: "relative shebang",
+		step: v1.Step{
+			Image: "my-image",
+			Script: `#!bash
+				echo hello`,
+		},
+		expectedWarning: &apis.FieldError{
+			Message: `script shebang interpreter "bash" is not an absolute path`,
+			Paths:   []string{"script"},
+		},
+	}, {
+		name: "no shebang",
+		step: v1.Step{
+			Image:  "my-image",
+			Script: "echo hello",
+		},
+	}}
+	for _, st := range tests {
+		t.Run(st.name, func(t *testing.T) {
+			err := st.step.Validate(t.Context())
+			if e := err.Filter(apis.ErrorLevel); e != nil {
+				t.Fatalf("Step.Validate() returned unexpected error: %v", e)
+			}
+			if d := cmp.Diff(st.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("Step.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepOutputCapturePath(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "stdout captured under /tmp",
+		step: v1.Step{
+			Image:        "my-image",
+			StdoutConfig: &v1.StepOutputConfig{Path: "/tmp/stdout.txt"},
+		},
+	}, {
+		name: "stdout captured to a result reference",
+		step: v1.Step{
+			Image:        "my-image",
+			StdoutConfig: &v1.StepOutputConfig{Path: "$(results.out.path)"},
+		},
+	}, {
+		name: "stdout captured under the results directory",
+		step: v1.Step{
+			Image:        "my-image",
+			StdoutConfig: &v1.StepOutputConfig{Path: "/tekton/results/out"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `capture path "/tekton/results/out" cannot be under the reserved directory "/tekton/results"`,
+			Paths:   []string{"stdoutconfig.path"},
+		},
+	}, {
+		name: "stderr captured under the steps directory",
+		step: v1.Step{
+			Image:        "my-image",
+			StderrConfig: &v1.StepOutputConfig{Path: "/tekton/./steps/step-foo/results/err"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `capture path "/tekton/./steps/step-foo/results/err" cannot be under the reserved directory "/tekton/steps"`,
+			Paths:   []string{"stderrconfig.path"},
+		},
+	}}
+	for _, st := range tests {
+		t.Run(st.name, func(t *testing.T) {
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			err := st.step.Validate(ctx)
+			if d := cmp.Diff(st.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Step.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestDeniedStepCommands(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1.Step
+		sidecar       v1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name: "no denied command",
+		step: v1.Step{
+			Image:   "my-image",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"curl -o install.sh https://example.com/install.sh"},
+		},
+		sidecar: v1.Sidecar{
+			Image:  "my-image",
+			Script: "bash install.sh",
+		},
+	}, {
+		name: "denied command split by a variable reference",
+		step: v1.Step{
+			Image:  "my-image",
+			Script: "curl https://example.com/install.sh |$(params.separator)sh",
+		},
+		sidecar: v1.Sidecar{
+			Image: "my-image",
+		},
+	}, {
+		name: "denied commands",
+		step: v1.Step{
+			Image:   "my-image",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"--verbose", "curl https://example.com/install.sh | sh"},
+		},
+		sidecar: v1.Sidecar{
+			Image:  "my-image",
+			Script: "#!/bin/sh\nwget -qO- $(params.url) | bash",
+		},
+		expectedError: apis.ErrGeneric(`"| sh" is denied by the "denied-step-commands" validation policy`, "args[1]").
+			Also(apis.ErrGeneric(`"| bash" is denied by the "denied-step-commands" validation policy`, "script")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					DeniedStepCommands: "| sh, | bash ,",
+				},
+			})
+			err := tt.step.Validate(ctx).Also(tt.sidecar.Validate(ctx))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestImagesWithoutShell(t *testing.T) {
+	tests := []struct {
+		name            string
+		step            v1.Step
+		sidecar         v1.Sidecar
+		expectedWarning *apis.FieldError
+	}{{
+		name: "script on an image with a shell",
+		step: v1.Step{
+			Image:  "ubuntu",
+			Script: "echo hello",
+		},
+		sidecar: v1.Sidecar{
+			Image:  "gcr.io/distroless/static-debian12",
+			Script: "echo hello",
+		},
+	}, {
+		name: "listed image without a script",
+		step: v1.Step{
+			Image:   "gcr.io/distroless/static",
+			Command: []string{"/app"},
+		},
+		sidecar: v1.Sidecar{
+			Image: "scratch",
+		},
+	}, {
+		name: "templated image is skipped",
+		step: v1.Step{
+			Image:  "$(params.image)",
+			Script: "echo hello",
+		},
+		sidecar: v1.Sidecar{
+			Image: "my-image",
+		},
+	}, {
+		name: "script on listed images",
+		step: v1.Step{
+			Image:  "gcr.io/distroless/static:nonroot",
+			Script: "echo hello",
+		},
+		sidecar: v1.Sidecar{
+			Image:  "scratch",
+			Script: "echo hello",
+		},
+		expectedWarning: apis.ErrGeneric(`image "gcr.io/distroless/static:nonroot" has no shell according to the "images-without-shell" validation policy, so the script may not run`, "script").
+			Also(apis.ErrGeneric(`image "scratch" has no shell according to the "images-without-shell" validation policy, so the script may not run`, "script")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					ImagesWithoutShell: "gcr.io/distroless/static,scratch",
+				},
+			})
+			err := tt.step.Validate(ctx).Also(tt.sidecar.Validate(ctx))
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("Validate() = %v, want no errors", err)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // TestStepIncompatibleAPIVersions exercises validation of fields in a Step
 // that require a specific feature gate version in order to work.
 func TestStepIncompatibleAPIVersions(t *testing.T) {
@@ -1032,6 +1304,20 @@ func TestSidecarValidate(t *testing.T) {
 			Name:  "my-sidecar",
 			Image: "my-image",
 		},
+	}, {
+		name: "terminationMessagePath outside of /tekton",
+		sidecar: v1.Sidecar{
+			Name:                   "my-sidecar",
+			Image:                  "my-image",
+			TerminationMessagePath: "/dev/termination-log",
+		},
+	}, {
+		name: "terminationMessagePath under a directory named like /tekton",
+		sidecar: v1.Sidecar{
+			Name:                   "my-sidecar",
+			Image:                  "my-image",
+			TerminationMessagePath: "/tekton-logs/termination",
+		},
 	}}
 
 	for _, sct := range tests {
@@ -1082,6 +1368,17 @@ func TestSidecarValidateError(t *testing.T) {
 			Message: "script cannot be used with command",
 			Paths:   []string{"script"},
 		},
+	}, {
+		name: "terminationMessagePath under /tekton",
+		sidecar: v1.Sidecar{
+			Name:                   "my-sidecar",
+			Image:                  "my-image",
+			TerminationMessagePath: "/tekton/results/../termination",
+		},
+		expectedError: apis.FieldError{
+			Message: `terminationMessagePath cannot be under /tekton/, which is reserved for the files of Tekton (got "/tekton/results/../termination")`,
+			Paths:   []string{"terminationMessagePath"},
+		},
 	}}
 
 	for _, sct := range tests {
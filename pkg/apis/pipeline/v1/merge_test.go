@@ -313,6 +313,7 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
+			original := append([]v1.Step(nil), tc.steps...)
 			result, err := v1.MergeStepsWithStepTemplate(tc.template, tc.steps)
 			if err != nil {
 				t.Errorf("expected no error. Got error %v", err)
@@ -321,6 +322,9 @@ func TestMergeStepsWithStepTemplate(t *testing.T) {
 			if d := cmp.Diff(tc.expected, result, resourceQuantityCmp); d != "" {
 				t.Errorf("merged steps don't match, diff: %s", diff.PrintWantGot(d))
 			}
+			if d := cmp.Diff(original, tc.steps, resourceQuantityCmp); d != "" {
+				t.Errorf("steps were modified by the merge, diff: %s", diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -20,8 +20,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
+	"unicode"
 
+	"github.com/google/uuid"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
@@ -40,7 +46,7 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string", "array" and "object", and "string" is the default.
+	// are currently "string", "array", "object" and "integer", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
 	// Description is a user-facing description of the parameter that may be
@@ -61,6 +67,21 @@ type ParamSpec struct {
 	// If Enum is not set, no input validation is performed for the param.
 	// +optional
 	Enum []string `json:"enum,omitempty"`
+	// AllowedPattern is a regular expression that the default value of the parameter must fully match.
+	// For array parameters each element of the default value must match it. AllowedPattern cannot be
+	// set for object parameters.
+	// +optional
+	AllowedPattern string `json:"allowedPattern,omitempty"`
+	// MinItems is the minimum number of elements the default value of an array parameter must have.
+	// +optional
+	MinItems int `json:"minItems,omitempty"`
+	// MaxItems is the maximum number of elements the default value of an array parameter can have.
+	// +optional
+	MaxItems int `json:"maxItems,omitempty"`
+	// Format is a semantic format of a string parameter, one of "date-time", "email", "uri" or "uuid".
+	// The default value of the parameter must be valid for the format.
+	// +optional
+	Format string `json:"format,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
@@ -73,33 +94,69 @@ type PropertySpec struct {
 }
 
 // SetDefaults set the default type
-func (pp *ParamSpec) SetDefaults(context.Context) {
+func (pp *ParamSpec) SetDefaults(ctx context.Context) {
 	if pp == nil {
 		return
 	}
 
+	// When explicit param types are required, the type is not inferred so that untyped params fail validation.
+	if pp.Type == "" && config.ValidationPolicyFromContextOrDefaults(ctx).RequireExplicitParamTypes {
+		pp.setDefaultsForProperties()
+		return
+	}
+
 	// Propagate inferred type to the parent ParamSpec's type, and default type to the PropertySpec's type
 	// The sequence to look at is type in ParamSpec -> properties -> type in default -> array/string/object value in default
 	// If neither `properties` or `default` section is provided, ParamTypeString will be the default type.
+	pp.Type = pp.inferType()
+	pp.setDefaultsForProperties()
+
+	// An object default supplied as a JSON string is converted to its structured form, malformed
+	// JSON or JSON declaring a key twice is left untouched to be reported by validation.
+	if pp.Type == ParamTypeObject && pp.Default.isJSONObjectString() {
+		var m map[string]string
+		if err := json.Unmarshal([]byte(pp.Default.StringVal), &m); err == nil && len(duplicateJSONObjectKeys(pp.Default.StringVal)) == 0 {
+			pp.Default = NewObject(m)
+		}
+	}
+
+	// The default of an array of objects param supplied as a list of objects, which does not unmarshal into
+	// a list of strings, is converted to a list of JSON objects.
+	if pp.Type == ParamTypeArray && pp.Properties != nil && pp.Default != nil && pp.Default.Type == ParamTypeString &&
+		strings.HasPrefix(strings.TrimSpace(pp.Default.StringVal), "[") {
+		var objects []map[string]string
+		if err := json.Unmarshal([]byte(pp.Default.StringVal), &objects); err == nil {
+			elements := make([]string, 0, len(objects))
+			for _, o := range objects {
+				b, err := json.Marshal(o)
+				if err != nil {
+					return
+				}
+				elements = append(elements, string(b))
+			}
+			pp.Default = &ParamValue{Type: ParamTypeArray, ArrayVal: elements}
+		}
+	}
+}
+
+// inferType returns the type of the param, inferred from its properties or default when it is not declared.
+func (pp ParamSpec) inferType() ParamType {
 	switch {
 	case pp.Type != "":
-		// If param type is provided by the author, do nothing but just set default type for PropertySpec in case `properties` section is provided.
-		pp.setDefaultsForProperties()
+		return pp.Type
 	case pp.Properties != nil:
-		pp.Type = ParamTypeObject
-		// Also set default type for PropertySpec
-		pp.setDefaultsForProperties()
+		return ParamTypeObject
 	case pp.Default == nil:
 		// ParamTypeString is the default value (when no type can be inferred from the default value)
-		pp.Type = ParamTypeString
+		return ParamTypeString
 	case pp.Default.Type != "":
-		pp.Type = pp.Default.Type
+		return pp.Default.Type
 	case pp.Default.ArrayVal != nil:
-		pp.Type = ParamTypeArray
+		return ParamTypeArray
 	case pp.Default.ObjectVal != nil:
-		pp.Type = ParamTypeObject
+		return ParamTypeObject
 	default:
-		pp.Type = ParamTypeString
+		return ParamTypeString
 	}
 }
 
@@ -112,6 +169,12 @@ func (pp *ParamSpec) setDefaultsForProperties() {
 	}
 }
 
+// HasDefault returns true if the param declares a default value, even an empty one such as an
+// empty array. A param without a default must be provided when the Task is run.
+func (p ParamSpec) HasDefault() bool {
+	return p.Default != nil
+}
+
 // GetNames returns all the names of the declared parameters
 func (ps ParamSpecs) GetNames() []string {
 	var names []string
@@ -121,7 +184,14 @@ func (ps ParamSpecs) GetNames() []string {
 	return names
 }
 
-// SortByType splits the input params into string params, array params, and object params, in that order
+// NameSet returns the names of the declared parameters as a set
+func (ps ParamSpecs) NameSet() sets.String {
+	return sets.NewString(ps.GetNames()...)
+}
+
+// SortByType splits the input params into string params, array params, and object params, in that order.
+// The scalar params, i.e. the integer params and the params without a type, hold a single value which
+// is referenced like the one of a string param, so they are sorted with the string params.
 func (ps ParamSpecs) SortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 	var stringParams, arrayParams, objectParams ParamSpecs
 	for _, p := range ps {
@@ -130,7 +200,7 @@ func (ps ParamSpecs) SortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 			arrayParams = append(arrayParams, p)
 		case ParamTypeObject:
 			objectParams = append(objectParams, p)
-		case ParamTypeString:
+		case ParamTypeString, ParamTypeInteger:
 			fallthrough
 		default:
 			stringParams = append(stringParams, p)
@@ -139,6 +209,66 @@ func (ps ParamSpecs) SortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 	return stringParams, arrayParams, objectParams
 }
 
+// FilterByType returns the params of the given type, in the order they are declared.
+// Params without a type are considered string params, as in SortByType.
+func (ps ParamSpecs) FilterByType(t ParamType) ParamSpecs {
+	var filtered ParamSpecs
+	for _, p := range ps {
+		pt := p.Type
+		if pt == "" {
+			pt = ParamTypeString
+		}
+		if pt == t {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// ToEnvVars returns an environment variable for each string or integer param, in the order they are
+// declared, whose value is a reference to the param. The variables are named after the params converted
+// to upper snake case and prefixed with prefix, e.g. with the prefix "PARAM_" the variable for the param
+// "gitRevision" is "PARAM_GIT_REVISION". Array and object params are skipped.
+func (ps ParamSpecs) ToEnvVars(prefix string) []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	for _, p := range ps {
+		if p.Type != "" && p.Type != ParamTypeString && p.Type != ParamTypeInteger {
+			continue
+		}
+		value := fmt.Sprintf("$(params.%s)", p.Name)
+		if strings.Contains(p.Name, ".") {
+			value = fmt.Sprintf("$(params[%q])", p.Name)
+		}
+		envs = append(envs, corev1.EnvVar{
+			Name:  prefix + toUpperSnakeCase(p.Name),
+			Value: value,
+		})
+	}
+	return envs
+}
+
+// toUpperSnakeCase converts a param name to upper snake case, splitting words on camel case boundaries
+// and replacing any character that is not a letter or a digit with an underscore.
+func toUpperSnakeCase(name string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range name {
+		switch {
+		case unicode.IsUpper(r):
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteRune('_')
+		}
+		prev = r
+	}
+	return b.String()
+}
+
 // ValidateNoDuplicateNames returns an error if any of the params have the same name
 func (ps ParamSpecs) ValidateNoDuplicateNames() *apis.FieldError {
 	var errs *apis.FieldError
@@ -149,6 +279,37 @@ func (ps ParamSpecs) ValidateNoDuplicateNames() *apis.FieldError {
 	return errs
 }
 
+// Merge merges override into ps by param name and returns the merged ParamSpecs.
+// A param in override replaces the default of the param with the same name in ps,
+// and params only present in override are appended after those in ps, in the order
+// they are declared. An error is returned if a param is declared with different types,
+// params without a type having the type inferred from their properties or default.
+func (ps ParamSpecs) Merge(override ParamSpecs) (ParamSpecs, *apis.FieldError) {
+	var errs *apis.FieldError
+	merged := make(ParamSpecs, 0, len(ps)+len(override))
+	indexes := map[string]int{}
+	for _, p := range ps {
+		indexes[p.Name] = len(merged)
+		merged = append(merged, p)
+	}
+	for _, o := range override {
+		idx, ok := indexes[o.Name]
+		if !ok {
+			indexes[o.Name] = len(merged)
+			merged = append(merged, o)
+			continue
+		}
+		if baseType, overrideType := merged[idx].inferType(), o.inferType(); baseType != overrideType {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param type %q conflicts with overriding param type %q", baseType, overrideType), "type").ViaFieldKey("params", o.Name))
+			continue
+		}
+		if o.Default != nil {
+			merged[idx].Default = o.Default
+		}
+	}
+	return merged, errs
+}
+
 // validateParamEnums validates feature flag, duplication and allowed types for Param Enum
 func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
@@ -157,7 +318,7 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 			continue
 		}
 		if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableParamEnum {
-			errs = errs.Also(errs, apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use Enum", config.EnableParamEnum), "").ViaKey(p.Name))
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("feature flag `%s` should be set to true to use Enum", config.EnableParamEnum), "").ViaKey(p.Name))
 		}
 		if p.Type != ParamTypeString {
 			errs = errs.Also(apis.ErrGeneric("enum can only be set with string type param", "").ViaKey(p.Name))
@@ -165,6 +326,12 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 		for dup := range findDups(p.Enum) {
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter enum value %v appears more than once", dup), "").ViaKey(p.Name))
 		}
+		// Enums are static, variables in their values are never substituted.
+		for _, v := range p.Enum {
+			if strings.Contains(v, "$(") {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("parameter enum value %q cannot reference variables", v), "enum").ViaKey(p.Name))
+			}
+		}
 		if p.Default != nil && p.Default.StringVal != "" {
 			if !slices.Contains(p.Enum, p.Default.StringVal) {
 				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %v not in the enum list", p.Default.StringVal), "").ViaKey(p.Name))
@@ -174,6 +341,122 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// validateParamPatterns validates feature flag for AllowedPattern, that it is a valid regular expression
+// which is not set for object params, and that the default values match it
+func (ps ParamSpecs) validateParamPatterns(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.AllowedPattern == "" {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "allowedPattern", config.AlphaAPIFields).ViaField("allowedPattern").ViaKey(p.Name))
+		if p.Type == ParamTypeObject {
+			errs = errs.Also(apis.ErrGeneric("allowedPattern cannot be set with object type param", "allowedPattern").ViaKey(p.Name))
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p.AllowedPattern + ")$")
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(p.AllowedPattern, "allowedPattern", err.Error()).ViaKey(p.Name))
+			continue
+		}
+		if p.Default == nil {
+			continue
+		}
+		switch p.Default.Type {
+		case ParamTypeArray:
+			for i, v := range p.Default.ArrayVal {
+				if !matchesPattern(re, v) {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q does not match the allowed pattern %q", v, p.AllowedPattern), "").ViaFieldIndex("default", i).ViaKey(p.Name))
+				}
+			}
+		case ParamTypeString:
+			if !matchesPattern(re, p.Default.StringVal) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q does not match the allowed pattern %q", p.Default.StringVal, p.AllowedPattern), "default").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// validateParamItemCounts validates feature flag for MinItems and MaxItems, that they are only set for
+// array params, are consistent with each other, and that the default values have a number of elements within them
+func (ps ParamSpecs) validateParamItemCounts(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.MinItems == 0 && p.MaxItems == 0 {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "minItems and maxItems", config.AlphaAPIFields).ViaKey(p.Name))
+		if p.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrGeneric("minItems and maxItems can only be set with array type param", "").ViaKey(p.Name))
+			continue
+		}
+		if p.MinItems < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(p.MinItems, "minItems").ViaKey(p.Name))
+		}
+		if p.MaxItems < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(p.MaxItems, "maxItems").ViaKey(p.Name))
+		}
+		if p.MaxItems > 0 && p.MinItems > p.MaxItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("minItems %d is greater than maxItems %d", p.MinItems, p.MaxItems), "minItems", "maxItems").ViaKey(p.Name))
+			continue
+		}
+		if p.Default == nil || p.Default.Type != ParamTypeArray {
+			continue
+		}
+		if n := len(p.Default.ArrayVal); n < p.MinItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value has %d items, fewer than the minimum of %d", n, p.MinItems), "default").ViaKey(p.Name))
+		} else if p.MaxItems > 0 && n > p.MaxItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value has %d items, more than the maximum of %d", n, p.MaxItems), "default").ViaKey(p.Name))
+		}
+	}
+	return errs
+}
+
+// paramFormats are the formats a string param can declare, with the function checking that a value is valid for it.
+var paramFormats = map[string]func(string) bool{
+	"date-time": func(v string) bool {
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	},
+	"email": func(v string) bool {
+		addr, err := mail.ParseAddress(v)
+		return err == nil && addr.Address == v
+	},
+	"uri": func(v string) bool {
+		u, err := url.Parse(v)
+		return err == nil && u.Scheme != ""
+	},
+	"uuid": func(v string) bool {
+		// uuid.Parse also accepts the URN and braced forms, only the canonical form is a valid format.
+		_, err := uuid.Parse(v)
+		return err == nil && len(v) == 36
+	},
+}
+
+// validateFormat validates feature flag for Format, that it is a known format which is only set for
+// string params, and that the default value is valid for it
+func (p ParamSpec) validateFormat(ctx context.Context) *apis.FieldError {
+	errs := config.ValidateEnabledAPIFields(ctx, "format", config.AlphaAPIFields).ViaField(p.Name + ".format")
+	if p.Type != ParamTypeString {
+		return errs.Also(apis.ErrGeneric("format can only be set with string type param", p.Name+".format"))
+	}
+	valid, ok := paramFormats[p.Format]
+	if !ok {
+		return errs.Also(apis.ErrInvalidValue(p.Format, p.Name+".format", fmt.Sprintf("allowed formats are %q", sets.StringKeySet(paramFormats).List())))
+	}
+	if p.Default != nil && p.Default.Type == ParamTypeString && !strings.Contains(p.Default.StringVal, "$(") && !valid(p.Default.StringVal) {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q is not a valid %s", p.Default.StringVal, p.Format), p.Name+".default"))
+	}
+	return errs
+}
+
+// matchesPattern returns true if the value matches the pattern, or contains variables which can
+// only be checked once they are replaced
+func matchesPattern(re *regexp.Regexp, value string) bool {
+	return strings.Contains(value, "$(") || re.MatchString(value)
+}
+
 // findDups returns the duplicate element in the given slice
 func findDups(vals []string) sets.String {
 	seen := sets.String{}
@@ -477,10 +760,21 @@ const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
 	ParamTypeObject ParamType = "object"
+	// ParamTypeInteger is a string param whose value must be a whole number.
+	// Its values are stored as strings in ParamValue.
+	ParamTypeInteger ParamType = "integer"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject, ParamTypeInteger}
+
+// validParamTypes holds AllParamTypes for constant time lookups.
+var validParamTypes = sets.New(AllParamTypes...)
+
+// IsValidParamType returns true if the given type is one of AllParamTypes.
+func IsValidParamType(t ParamType) bool {
+	return validParamTypes.Has(t)
+}
 
 // ParamValues is modeled after IntOrString in kubernetes/apimachinery:
 
@@ -536,6 +830,41 @@ func (paramValues *ParamValue) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
+// isJSONObjectString returns true if the ParamValue is a string holding what is meant to be a JSON object,
+// as some clients supply object values in this form.
+func (paramValues *ParamValue) isJSONObjectString() bool {
+	return paramValues != nil && paramValues.Type == ParamTypeString && strings.HasPrefix(strings.TrimSpace(paramValues.StringVal), "{")
+}
+
+// duplicateJSONObjectKeys returns the sorted keys declared more than once at the top level of the JSON
+// object s. Unmarshalling such an object silently keeps the last value of each key.
+func duplicateJSONObjectKeys(s string) []string {
+	dec := json.NewDecoder(strings.NewReader(s))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	seen, duplicates := sets.NewString(), sets.NewString()
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			break
+		}
+		key, ok := t.(string)
+		if !ok {
+			break
+		}
+		if seen.Has(key) {
+			duplicates.Insert(key)
+		}
+		seen.Insert(key)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			break
+		}
+	}
+	return duplicates.List()
+}
+
 // MarshalJSON implements the json.Marshaller interface.
 func (paramValues ParamValue) MarshalJSON() ([]byte, error) {
 	switch paramValues.Type {
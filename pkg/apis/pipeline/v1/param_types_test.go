@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
 )
@@ -147,6 +148,56 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 				ObjectVal: map[string]string{"url": "test", "path": "test"},
 			},
 		},
+	}, {
+		name: "object default as JSON string",
+		before: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": "test", "path": "test"}`),
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewObject(map[string]string{"url": "test", "path": "test"}),
+		},
+	}, {
+		name: "object default as malformed JSON string",
+		before: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": `),
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": `),
+		},
+	}, {
+		name: "object default as JSON string with duplicate keys",
+		before: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": "test", "url": "other"}`),
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:    "parametername",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": "test", "url": "other"}`),
+		},
+	}, {
+		name: "array of objects default as list of objects",
+		before: &v1.ParamSpec{
+			Name:       "parametername",
+			Type:       v1.ParamTypeArray,
+			Properties: map[string]v1.PropertySpec{"name": {}},
+			Default:    &v1.ParamValue{Type: v1.ParamTypeString, StringVal: `[{"name": "a"}, {"name": "b"}]`},
+		},
+		defaultsApplied: &v1.ParamSpec{
+			Name:       "parametername",
+			Type:       v1.ParamTypeArray,
+			Properties: map[string]v1.PropertySpec{"name": {Type: v1.ParamTypeString}},
+			Default:    &v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{`{"name":"a"}`, `{"name":"b"}`}},
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -159,6 +210,57 @@ func TestParamSpec_SetDefaults(t *testing.T) {
 	}
 }
 
+func TestParamSpec_HasDefault(t *testing.T) {
+	tcs := []struct {
+		name    string
+		param   v1.ParamSpec
+		want    bool
+		wantErr bool
+	}{{
+		name: "nil default",
+		param: v1.ParamSpec{
+			Name: "arr",
+			Type: v1.ParamTypeArray,
+		},
+		want: false,
+	}, {
+		name: "empty array default",
+		param: v1.ParamSpec{
+			Name:    "arr",
+			Type:    v1.ParamTypeArray,
+			Default: &v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{}},
+		},
+		want: true,
+	}, {
+		name: "populated array default",
+		param: v1.ParamSpec{
+			Name:    "arr",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("a", "b"),
+		},
+		want: true,
+	}, {
+		name: "empty default of another type",
+		param: v1.ParamSpec{
+			Name:    "arr",
+			Type:    v1.ParamTypeArray,
+			Default: &v1.ParamValue{Type: v1.ParamTypeString},
+		},
+		want:    true,
+		wantErr: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.param.HasDefault(); got != tc.want {
+				t.Errorf("HasDefault() = %t, want %t", got, tc.want)
+			}
+			if err := tc.param.ValidateType(t.Context()); (err != nil) != tc.wantErr {
+				t.Errorf("ValidateType() = %v, wantErr %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestParamValues_ApplyReplacements(t *testing.T) {
 	type args struct {
 		input              *v1.ParamValue
@@ -714,6 +816,47 @@ func TestGetNames(t *testing.T) {
 	}
 }
 
+func TestNameSet(t *testing.T) {
+	tcs := []struct {
+		name   string
+		params v1.ParamSpecs
+	}{{
+		name: "no params",
+	}, {
+		name: "names from param spec",
+		params: v1.ParamSpecs{{
+			Name: "foo",
+		}, {
+			Name: "bar",
+		}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			want := sets.NewString(tc.params.GetNames()...)
+			got := tc.params.NameSet()
+			if d := cmp.Diff(want.List(), got.List()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestIsValidParamType(t *testing.T) {
+	if got, want := sets.New(v1.AllParamTypes...).Len(), len(v1.AllParamTypes); got != want {
+		t.Errorf("AllParamTypes declares %d types, want %d unique types", want, got)
+	}
+	for _, paramType := range v1.AllParamTypes {
+		if !v1.IsValidParamType(paramType) {
+			t.Errorf("IsValidParamType(%q) = false, want true", paramType)
+		}
+	}
+	for _, paramType := range []v1.ParamType{"", "number", "String"} {
+		if v1.IsValidParamType(paramType) {
+			t.Errorf("IsValidParamType(%q) = true, want false", paramType)
+		}
+	}
+}
+
 func TestSortByType(t *testing.T) {
 	tcs := []struct {
 		name   string
@@ -763,6 +906,36 @@ func TestSortByType(t *testing.T) {
 				Type: "object",
 			}},
 		},
+	}, {
+		name: "scalar params are sorted with the string params",
+		params: v1.ParamSpecs{{
+			Name: "integer1",
+			Type: v1.ParamTypeInteger,
+		}, {
+			Name: "array1",
+			Type: v1.ParamTypeArray,
+		}, {
+			Name: "untyped1",
+		}, {
+			Name: "string1",
+			Type: v1.ParamTypeString,
+		}},
+		want: []v1.ParamSpecs{
+			{{
+				Name: "integer1",
+				Type: v1.ParamTypeInteger,
+			}, {
+				Name: "untyped1",
+			}, {
+				Name: "string1",
+				Type: v1.ParamTypeString,
+			}},
+			{{
+				Name: "array1",
+				Type: v1.ParamTypeArray,
+			}},
+			nil,
+		},
 	}}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -775,6 +948,77 @@ func TestSortByType(t *testing.T) {
 	}
 }
 
+func TestFilterByType(t *testing.T) {
+	params := v1.ParamSpecs{{
+		Name: "array1",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "string1",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "object1",
+		Type: v1.ParamTypeObject,
+	}, {
+		Name: "integer1",
+		Type: v1.ParamTypeInteger,
+	}, {
+		Name: "untyped1",
+	}, {
+		Name: "array2",
+		Type: v1.ParamTypeArray,
+	}}
+	tcs := []struct {
+		name      string
+		paramType v1.ParamType
+		want      v1.ParamSpecs
+	}{{
+		name:      "string params include untyped params",
+		paramType: v1.ParamTypeString,
+		want: v1.ParamSpecs{{
+			Name: "string1",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "untyped1",
+		}},
+	}, {
+		name:      "array params",
+		paramType: v1.ParamTypeArray,
+		want: v1.ParamSpecs{{
+			Name: "array1",
+			Type: v1.ParamTypeArray,
+		}, {
+			Name: "array2",
+			Type: v1.ParamTypeArray,
+		}},
+	}, {
+		name:      "object params",
+		paramType: v1.ParamTypeObject,
+		want: v1.ParamSpecs{{
+			Name: "object1",
+			Type: v1.ParamTypeObject,
+		}},
+	}, {
+		name:      "integer params",
+		paramType: v1.ParamTypeInteger,
+		want: v1.ParamSpecs{{
+			Name: "integer1",
+			Type: v1.ParamTypeInteger,
+		}},
+	}, {
+		name:      "no params of type",
+		paramType: v1.ParamType("bool"),
+		want:      nil,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := params.FilterByType(tc.paramType)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidateNoDuplicateNames(t *testing.T) {
 	tcs := []struct {
 		name          string
@@ -808,3 +1052,163 @@ func TestValidateNoDuplicateNames(t *testing.T) {
 		})
 	}
 }
+
+func TestParamSpecsMerge(t *testing.T) {
+	tcs := []struct {
+		name          string
+		base          v1.ParamSpecs
+		override      v1.ParamSpecs
+		want          v1.ParamSpecs
+		expectedError *apis.FieldError
+	}{{
+		name: "clean merge",
+		base: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "bar",
+			Type: v1.ParamTypeArray,
+		}},
+		override: v1.ParamSpecs{{
+			Name: "baz",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "bar",
+			Type: v1.ParamTypeArray,
+		}},
+		want: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "bar",
+			Type: v1.ParamTypeArray,
+		}, {
+			Name: "baz",
+			Type: v1.ParamTypeString,
+		}},
+	}, {
+		name: "type conflict",
+		base: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}},
+		override: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeArray,
+		}},
+		want: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param type "string" conflicts with overriding param type "array"`,
+			Paths:   []string{"params[foo].type"},
+		},
+	}, {
+		name: "default override",
+		base: v1.ParamSpecs{{
+			Name:        "foo",
+			Type:        v1.ParamTypeString,
+			Description: "base description",
+			Default:     v1.NewStructuredValues("base"),
+		}},
+		override: v1.ParamSpecs{{
+			Name:    "foo",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("override"),
+		}},
+		want: v1.ParamSpecs{{
+			Name:        "foo",
+			Type:        v1.ParamTypeString,
+			Description: "base description",
+			Default:     v1.NewStructuredValues("override"),
+		}},
+	}, {
+		name: "untyped string default conflicts with array",
+		base: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeArray,
+		}},
+		override: v1.ParamSpecs{{
+			Name:    "foo",
+			Default: v1.NewStructuredValues("override"),
+		}},
+		want: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeArray,
+		}},
+		expectedError: &apis.FieldError{
+			Message: `param type "array" conflicts with overriding param type "string"`,
+			Paths:   []string{"params[foo].type"},
+		},
+	}, {
+		name: "untyped array default override",
+		base: v1.ParamSpecs{{
+			Name: "foo",
+			Type: v1.ParamTypeArray,
+		}},
+		override: v1.ParamSpecs{{
+			Name:    "foo",
+			Default: v1.NewStructuredValues("a", "b"),
+		}},
+		want: v1.ParamSpecs{{
+			Name:    "foo",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("a", "b"),
+		}},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.base.Merge(tc.override)
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamSpecs_ToEnvVars(t *testing.T) {
+	ps := v1.ParamSpecs{{
+		Name: "gitRevision",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "context-dir",
+	}, {
+		Name: "retry_count",
+		Type: v1.ParamTypeInteger,
+	}, {
+		Name: "image.tag",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "sha256Digest",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "flags",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "gitrepo",
+		Type: v1.ParamTypeObject,
+	}}
+	want := []corev1.EnvVar{{
+		Name:  "PARAM_GIT_REVISION",
+		Value: "$(params.gitRevision)",
+	}, {
+		Name:  "PARAM_CONTEXT_DIR",
+		Value: "$(params.context-dir)",
+	}, {
+		Name:  "PARAM_RETRY_COUNT",
+		Value: "$(params.retry_count)",
+	}, {
+		Name:  "PARAM_IMAGE_TAG",
+		Value: `$(params["image.tag"])`,
+	}, {
+		Name:  "PARAM_SHA256_DIGEST",
+		Value: "$(params.sha256Digest)",
+	}}
+	if d := cmp.Diff(want, ps.ToEnvVars("PARAM_")); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
@@ -401,9 +401,9 @@ func (ps *PipelineSpec) validatePipelineParameterUsage(ctx context.Context) (err
 
 // validatePipelineTaskParameterUsage validates that parameters referenced in the Pipeline Tasks are declared by the Pipeline
 func validatePipelineTaskParameterUsage(tasks []PipelineTask, params ParamSpecs) (errs *apis.FieldError) {
-	allParamNames := sets.NewString(params.GetNames()...)
+	allParamNames := params.NameSet()
 	_, arrayParams, objectParams := params.SortByType()
-	arrayParamNames := sets.NewString(arrayParams.GetNames()...)
+	arrayParamNames := arrayParams.NameSet()
 	objectParameterNameKeys := map[string][]string{}
 	for _, p := range objectParams {
 		for k := range p.Properties {
@@ -446,6 +446,8 @@ func ValidatePipelineParameterVariables(ctx context.Context, tasks []PipelineTas
 	errs = errs.Also(ValidateParameterTypes(ctx, params).ViaField("params"))
 	errs = errs.Also(params.ValidateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamPatterns(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamItemCounts(ctx).ViaField("params"))
 	for i, task := range tasks {
 		errs = errs.Also(task.Params.validateDuplicateParameters().ViaField("params").ViaIndex(i))
 	}
@@ -1589,6 +1589,7 @@ func TestFinallyTaskResultsToPipelineResults_Success(t *testing.T) {
 							}},
 							Steps: []Step{{
 								Name: "foo", Image: "bar",
+								Args: []string{"$(results.initialized.path)"},
 							}},
 						}},
 					}},
@@ -1612,6 +1613,7 @@ func TestFinallyTaskResultsToPipelineResults_Success(t *testing.T) {
 							}},
 							Steps: []Step{{
 								Name: "foo", Image: "bar",
+								Args: []string{"$(results.current-date-unix-timestamp.path)"},
 							}},
 						}},
 					}},
@@ -1624,6 +1626,7 @@ func TestFinallyTaskResultsToPipelineResults_Success(t *testing.T) {
 							}},
 							Steps: []Step{{
 								Name: "foo2", Image: "bar",
+								Args: []string{"$(results.init.path)"},
 							}},
 						}},
 					}},
@@ -1670,6 +1673,7 @@ func TestFinallyTaskResultsToPipelineResults_Failure(t *testing.T) {
 						}},
 						Steps: []Step{{
 							Name: "foo", Image: "bar",
+							Args: []string{"$(results.current-date-unix-timestamp.path)"},
 						}},
 					}},
 				}},
@@ -1682,6 +1686,7 @@ func TestFinallyTaskResultsToPipelineResults_Failure(t *testing.T) {
 						}},
 						Steps: []Step{{
 							Name: "foo2", Image: "bar",
+							Args: []string{"$(results.init.path)"},
 						}},
 					}},
 				}},
@@ -1709,6 +1714,7 @@ func TestFinallyTaskResultsToPipelineResults_Failure(t *testing.T) {
 						}},
 						Steps: []Step{{
 							Name: "foo", Image: "bar",
+							Args: []string{"$(results.current-date-unix-timestamp.path)"},
 						}},
 					}},
 				}},
@@ -1721,6 +1727,7 @@ func TestFinallyTaskResultsToPipelineResults_Failure(t *testing.T) {
 						}},
 						Steps: []Step{{
 							Name: "foo2", Image: "bar",
+							Args: []string{"$(results.init.path)"},
 						}},
 					}},
 				}},
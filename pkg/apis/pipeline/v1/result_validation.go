@@ -17,7 +17,9 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
@@ -39,9 +41,47 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	case tr.Type != ResultsTypeString:
 		errs = errs.Also(apis.ErrInvalidValue(tr.Type, "type", "type must be string"))
 	}
+	// Context variables are not replaced in the declaration of a result.
+	if strings.Contains(tr.Description, "$(context.") {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result description %q cannot reference context variables, they are not resolved in result declarations", tr.Description), "description"))
+	} else {
+		errs = errs.Also(warnDescriptionVariableReferences(tr.Description))
+	}
+	errs = errs.Also(validateDescription(ctx, tr.Description))
 	return errs.Also(tr.validateValue(ctx))
 }
 
+// validateDescription returns an error if the description is missing while the
+// "require-descriptions" policy is enabled, or longer than the "max-description-length"
+// policy allows.
+func validateDescription(ctx context.Context, description string) *apis.FieldError {
+	policy := config.ValidationPolicyFromContextOrDefaults(ctx)
+	if description == "" && policy.RequireDescriptions {
+		return &apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"description"},
+			Details: fmt.Sprintf("validation policy %q requires results and params to declare a description", "require-descriptions"),
+		}
+	}
+	if policy.MaxDescriptionLength == 0 || len(description) <= policy.MaxDescriptionLength {
+		return nil
+	}
+	return apis.ErrGeneric(fmt.Sprintf("description is %d bytes long, longer than the maximum of %d bytes", len(description), policy.MaxDescriptionLength), "description")
+}
+
+// descriptionVariableReferenceRegex matches the variable references in a description, e.g. $(params.foo),
+// except for the escaped ones, e.g. $$(params.foo).
+var descriptionVariableReferenceRegex = regexp.MustCompile(`(?:^|[^$])(\$\([^()]*\))`)
+
+// warnDescriptionVariableReferences returns a warning for each variable reference in the description,
+// since descriptions are never substituted.
+func warnDescriptionVariableReferences(description string) (errs *apis.FieldError) {
+	for _, match := range descriptionVariableReferenceRegex.FindAllStringSubmatch(description, -1) {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("description references %s, variables are not substituted in descriptions", match[1]), "description").At(apis.WarningLevel))
+	}
+	return errs
+}
+
 // validateObjectResult validates the object result and check if the Properties is missing
 // for Properties values it will check if the type is string.
 func validateObjectResult(tr TaskResult) (errs *apis.FieldError) {
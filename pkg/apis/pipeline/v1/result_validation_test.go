@@ -60,6 +60,12 @@ func TestResultsValidate(t *testing.T) {
 			Description: "my great result",
 			Properties:  map[string]v1.PropertySpec{"hello": {Type: v1.ParamTypeString}},
 		},
+	}, {
+		name: "valid result description mentioning context",
+		Result: v1.TaskResult{
+			Name:        "MY-RESULT",
+			Description: "the name of the TaskRun, taken from its context",
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -111,6 +117,16 @@ func TestResultsValidateError(t *testing.T) {
 			Message: "missing field(s)",
 			Paths:   []string{"MY-RESULT.properties"},
 		},
+	}, {
+		name: "context variable in result description",
+		Result: v1.TaskResult{
+			Name:        "MY-RESULT",
+			Description: "the digest of the image built by $(context.taskRun.name)",
+		},
+		expectedError: apis.FieldError{
+			Message: `result description "the digest of the image built by $(context.taskRun.name)" cannot reference context variables, they are not resolved in result declarations`,
+			Paths:   []string{"description"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// previewParamPatterns are the reference patterns of a param, using dot or bracket notation.
+var previewParamPatterns = []string{"params.%s", "params[%q]", "params['%s']"}
+
+// PreviewStep returns the step at index i, merged with the StepTemplate and with the params substituted
+// by their default values, or by the values in overrides. It is a read-only preview for tooling: the
+// TaskSpec is not modified, and the variables which are not params, such as workspaces or results,
+// are left as they are. An error is returned for the params which are not resolved, e.g. when they
+// are not declared or have neither a default nor an override.
+func (ts *TaskSpec) PreviewStep(i int, overrides map[string]ParamValue) (Step, *apis.FieldError) {
+	if i < 0 || i >= len(ts.Steps) {
+		return Step{}, apis.ErrOutOfBoundsValue(i, 0, len(ts.Steps)-1, "steps")
+	}
+	var errs *apis.FieldError
+	declared := ts.Params.NameSet()
+	for _, name := range sets.StringKeySet(overrides).List() {
+		if !declared.Has(name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("override for param %q, which is not declared", name), "params"))
+		}
+	}
+	steps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
+	if err != nil {
+		return Step{}, errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("error merging step template and steps: %s", err),
+			Paths:   []string{"stepTemplate"},
+			Details: err.Error(),
+		})
+	}
+	step := *steps[i].DeepCopy()
+
+	stringReplacements, arrayReplacements := ts.previewReplacements(overrides)
+	step.Name = substitution.ApplyReplacements(step.Name, stringReplacements)
+	step.Image = substitution.ApplyReplacements(step.Image, stringReplacements)
+	step.WorkingDir = substitution.ApplyReplacements(step.WorkingDir, stringReplacements)
+	step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
+	step.OnError = OnErrorType(substitution.ApplyReplacements(string(step.OnError), stringReplacements))
+	step.Command = previewArrayReplacements(step.Command, stringReplacements, arrayReplacements)
+	step.Args = previewArrayReplacements(step.Args, stringReplacements, arrayReplacements)
+	for j := range step.Env {
+		step.Env[j].Value = substitution.ApplyReplacements(step.Env[j].Value, stringReplacements)
+	}
+	for j := range step.EnvFrom {
+		step.EnvFrom[j].Prefix = substitution.ApplyReplacements(step.EnvFrom[j].Prefix, stringReplacements)
+		if step.EnvFrom[j].ConfigMapRef != nil {
+			step.EnvFrom[j].ConfigMapRef.Name = substitution.ApplyReplacements(step.EnvFrom[j].ConfigMapRef.Name, stringReplacements)
+		}
+		if step.EnvFrom[j].SecretRef != nil {
+			step.EnvFrom[j].SecretRef.Name = substitution.ApplyReplacements(step.EnvFrom[j].SecretRef.Name, stringReplacements)
+		}
+	}
+	for j := range step.VolumeMounts {
+		step.VolumeMounts[j].Name = substitution.ApplyReplacements(step.VolumeMounts[j].Name, stringReplacements)
+		step.VolumeMounts[j].MountPath = substitution.ApplyReplacements(step.VolumeMounts[j].MountPath, stringReplacements)
+		step.VolumeMounts[j].SubPath = substitution.ApplyReplacements(step.VolumeMounts[j].SubPath, stringReplacements)
+	}
+	for _, f := range securityContextStringFields(step.SecurityContext) {
+		*f.value = substitution.ApplyReplacements(*f.value, stringReplacements)
+	}
+
+	// Any param reference left in the rendered step could not be resolved.
+	errs = errs.Also(validateStepVariables(context.Background(), step, "params", sets.NewString()).ViaIndex(i).ViaField("steps"))
+	return step, errs
+}
+
+// previewReplacements returns the string and array replacements of the params of the TaskSpec, using
+// the values in overrides or else the defaults of the params. String values referencing other params
+// are resolved once, like the defaults of a TaskRun.
+func (ts *TaskSpec) previewReplacements(overrides map[string]ParamValue) (map[string]string, map[string][]string) {
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	for _, p := range ts.Params {
+		value := p.Default
+		if override, ok := overrides[p.Name]; ok {
+			value = &override
+		}
+		if value == nil {
+			continue
+		}
+		switch value.Type {
+		case ParamTypeArray:
+			for _, pattern := range previewParamPatterns {
+				key := fmt.Sprintf(pattern, p.Name)
+				for idx, v := range value.ArrayVal {
+					stringReplacements[fmt.Sprintf("%s[%d]", key, idx)] = v
+				}
+				arrayReplacements[key] = value.ArrayVal
+			}
+		case ParamTypeObject:
+			for k, v := range value.ObjectVal {
+				stringReplacements[fmt.Sprintf("params.%s.%s", p.Name, k)] = v
+				stringReplacements[fmt.Sprintf("params.%s[%q]", p.Name, k)] = v
+			}
+		default:
+			for _, pattern := range previewParamPatterns {
+				stringReplacements[fmt.Sprintf(pattern, p.Name)] = value.StringVal
+			}
+		}
+	}
+	resolved := make(map[string]string, len(stringReplacements))
+	for k, v := range stringReplacements {
+		resolved[k] = substitution.ApplyReplacements(v, stringReplacements)
+	}
+	return resolved, arrayReplacements
+}
+
+// previewArrayReplacements applies the replacements to each of the values, expanding the isolated
+// references to whole array params.
+func previewArrayReplacements(values []string, stringReplacements map[string]string, arrayReplacements map[string][]string) []string {
+	if values == nil {
+		return nil
+	}
+	replaced := []string{}
+	for _, v := range values {
+		replaced = append(replaced, substitution.ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
+	}
+	return replaced
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskSpecPreviewStep(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name:    "image",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("alpine"),
+		}, {
+			Name:    "tag",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("$(params.version)"),
+		}, {
+			Name:    "version",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("3.20"),
+		}, {
+			Name:    "flags",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("-a", "-b"),
+		}, {
+			Name: "repo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url": {Type: v1.ParamTypeString},
+			},
+			Default: v1.NewObject(map[string]string{"url": "https://example.com"}),
+		}, {
+			Name: "revision",
+			Type: v1.ParamTypeString,
+		}},
+		StepTemplate: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{Name: "URL", Value: "$(params.repo.url)"}},
+		},
+		Steps: []v1.Step{{
+			Name:    "build",
+			Image:   "$(params.image):$(params.tag)",
+			Command: []string{"build"},
+			Args:    []string{"$(params.flags[*])", "--first=$(params.flags[0])", "--out=$(workspaces.out.path)"},
+		}, {
+			Name:   "checkout",
+			Image:  "git",
+			Script: "git checkout $(params.revision)",
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		index         int
+		overrides     map[string]v1.ParamValue
+		want          v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name:  "defaults",
+		index: 0,
+		want: v1.Step{
+			Name:    "build",
+			Image:   "alpine:3.20",
+			Command: []string{"build"},
+			Args:    []string{"-a", "-b", "--first=-a", "--out=$(workspaces.out.path)"},
+			Env:     []corev1.EnvVar{{Name: "URL", Value: "https://example.com"}},
+		},
+	}, {
+		name:  "overrides",
+		index: 0,
+		overrides: map[string]v1.ParamValue{
+			"image": *v1.NewStructuredValues("ubuntu"),
+			"flags": *v1.NewStructuredValues("-c", "-d"),
+			"repo":  *v1.NewObject(map[string]string{"url": "https://example.org"}),
+		},
+		want: v1.Step{
+			Name:    "build",
+			Image:   "ubuntu:3.20",
+			Command: []string{"build"},
+			Args:    []string{"-c", "-d", "--first=-c", "--out=$(workspaces.out.path)"},
+			Env:     []corev1.EnvVar{{Name: "URL", Value: "https://example.org"}},
+		},
+	}, {
+		name:  "param without default",
+		index: 1,
+		want: v1.Step{
+			Name:   "checkout",
+			Image:  "git",
+			Script: "git checkout $(params.revision)",
+			Env:    []corev1.EnvVar{{Name: "URL", Value: "https://example.com"}},
+		},
+		expectedError: apis.ErrGeneric(`non-existent variable in "git checkout $(params.revision)"`, "steps[1].script"),
+	}, {
+		name:          "undeclared override",
+		index:         1,
+		overrides:     map[string]v1.ParamValue{"revision": *v1.NewStructuredValues("main"), "branch": *v1.NewStructuredValues("main")},
+		expectedError: apis.ErrGeneric(`override for param "branch", which is not declared`, "params"),
+		want: v1.Step{
+			Name:   "checkout",
+			Image:  "git",
+			Script: "git checkout main",
+			Env:    []corev1.EnvVar{{Name: "URL", Value: "https://example.com"}},
+		},
+	}, {
+		name:          "index out of range",
+		index:         2,
+		expectedError: apis.ErrOutOfBoundsValue(2, 0, 1, "steps"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := ts.DeepCopy()
+			got, err := ts.PreviewStep(tt.index, tt.overrides)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PreviewStep() errors diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tt.want, got); d != "" {
+				t.Errorf("PreviewStep() step diff %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, ts); d != "" {
+				t.Errorf("PreviewStep() modified the TaskSpec %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
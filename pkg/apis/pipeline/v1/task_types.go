@@ -17,14 +17,22 @@ limitations under the License.
 package v1
 
 import (
+	"context"
+	"strings"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/checksum"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/kmeta"
 )
 
+// TargetPlatformAnnotation is the annotation a Task uses to declare the platform, e.g. "linux/arm64",
+// its images are meant to run on.
+const TargetPlatformAnnotation = "tekton.dev/target-platform"
+
 // +genclient
 // +genclient:noStatus
 // +genreconciler:krshapedlogic=false
@@ -75,6 +83,37 @@ func (t *Task) Checksum() ([]byte, error) {
 	return sha256Checksum, nil
 }
 
+// EquivalentTo returns true if the specs of the two Tasks are semantically equal, which is useful
+// to detect drift between a Task and its source of truth. The metadata of the Tasks is not compared.
+// Both specs are normalized before being compared, so the following differences are ignored:
+//   - values set by defaulting, such as inferred param types
+//   - a workspace mount path left empty versus set to its default "/workspace/<name>"
+//   - leading and trailing whitespace in step and sidecar scripts
+//   - empty versus nil lists and maps (map entries are unordered so their ordering never matters)
+func (t *Task) EquivalentTo(other *Task) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return equality.Semantic.DeepEqual(t.Spec.normalized(), other.Spec.normalized())
+}
+
+// normalized returns a defaulted copy of the TaskSpec with the differences ignored by
+// Task.EquivalentTo removed.
+func (ts *TaskSpec) normalized() *TaskSpec {
+	n := ts.DeepCopy()
+	n.SetDefaults(context.Background())
+	for i := range n.Workspaces {
+		n.Workspaces[i].MountPath = n.Workspaces[i].GetMountPath()
+	}
+	for i := range n.Steps {
+		n.Steps[i].Script = strings.TrimSpace(n.Steps[i].Script)
+	}
+	for i := range n.Sidecars {
+		n.Sidecars[i].Script = strings.TrimSpace(n.Sidecars[i].Script)
+	}
+	return n
+}
+
 // +listType=atomic
 type Volumes []corev1.Volume
 
@@ -126,6 +165,18 @@ type TaskSpec struct {
 	Results []TaskResult `json:"results,omitempty"`
 }
 
+// StepByName returns the step with the given name, its index and whether it was found.
+// It looks up the raw Steps of the TaskSpec, so the returned step has not been merged with
+// the StepTemplate; the returned pointer refers to the element of ts.Steps.
+func (ts *TaskSpec) StepByName(name string) (*Step, int, bool) {
+	for i := range ts.Steps {
+		if ts.Steps[i].Name == name {
+			return &ts.Steps[i], i, true
+		}
+	}
+	return nil, -1, false
+}
+
 // TaskList contains a list of Task
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 type TaskList struct {
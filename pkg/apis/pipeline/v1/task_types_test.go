@@ -88,3 +88,129 @@ func TestTask_Checksum(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskSpec_StepByName(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:  "first",
+			Image: "ubuntu",
+		}, {
+			Name:  "second",
+			Image: "busybox",
+		}},
+	}
+	tests := []struct {
+		name      string
+		stepName  string
+		wantStep  *v1.Step
+		wantIndex int
+		wantFound bool
+	}{{
+		name:      "found",
+		stepName:  "second",
+		wantStep:  &ts.Steps[1],
+		wantIndex: 1,
+		wantFound: true,
+	}, {
+		name:      "not found",
+		stepName:  "third",
+		wantIndex: -1,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, index, found := ts.StepByName(tt.stepName)
+			if found != tt.wantFound {
+				t.Errorf("StepByName() found = %t, want %t", found, tt.wantFound)
+			}
+			if index != tt.wantIndex {
+				t.Errorf("StepByName() index = %d, want %d", index, tt.wantIndex)
+			}
+			if step != tt.wantStep {
+				t.Errorf("StepByName() step = %v, want %v", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestTask_EquivalentTo(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task", ResourceVersion: "1"},
+		Spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "source",
+			}},
+			Steps: []v1.Step{{
+				Name:   "clone",
+				Image:  "alpine/git",
+				Script: "git clone $(params.gitrepo.url)",
+			}},
+		},
+	}
+	tests := []struct {
+		name  string
+		other *v1.Task
+		want  bool
+	}{{
+		name: "reordered properties and cosmetic differences",
+		other: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task", ResourceVersion: "2"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "gitrepo",
+					Type: v1.ParamTypeObject,
+					Properties: map[string]v1.PropertySpec{
+						"commit": {Type: v1.ParamTypeString},
+						"url":    {Type: v1.ParamTypeString},
+					},
+				}},
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name:      "source",
+					MountPath: "/workspace/source",
+				}},
+				Steps: []v1.Step{{
+					Name:   "clone",
+					Image:  "alpine/git",
+					Script: "\n  git clone $(params.gitrepo.url)\n",
+				}},
+			},
+		},
+		want: true,
+	}, {
+		name: "changed image",
+		other: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Params:     task.Spec.Params,
+				Workspaces: task.Spec.Workspaces,
+				Steps: []v1.Step{{
+					Name:   "clone",
+					Image:  "alpine/git:v2",
+					Script: "git clone $(params.gitrepo.url)",
+				}},
+			},
+		},
+		want: false,
+	}, {
+		name:  "nil task",
+		other: nil,
+		want:  false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := task.DeepCopy()
+			if got := task.EquivalentTo(tt.other); got != tt.want {
+				t.Errorf("Task.EquivalentTo() = %t, want %t", got, tt.want)
+			}
+			if d := cmp.Diff(original, task); d != "" {
+				t.Errorf("Task.EquivalentTo() modified the receiver %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
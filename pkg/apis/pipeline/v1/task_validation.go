@@ -18,12 +18,21 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -57,6 +66,9 @@ func (t *Task) SupportedVerbs() []admissionregistrationv1.OperationType {
 var (
 	stringAndArrayVariableNameFormatRegex = regexp.MustCompile(stringAndArrayVariableNameFormat)
 	objectVariableNameFormatRegex         = regexp.MustCompile(objectVariableNameFormat)
+	// malformedStepResultRegex matches $(steps.results.<name>) and $(steps.results.<name>.path), which mix
+	// up the reference to a result of the current step with the one to a result of another step.
+	malformedStepResultRegex = regexp.MustCompile(`\$\(steps\.results\.([^.)\s]+)(\.path)?\)`)
 )
 
 // Validate implements apis.Validatable
@@ -66,18 +78,314 @@ func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
 	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(validateStepTemplateUsageOfDeclaredParameters(ctx, t.Spec.StepTemplate, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(validateSidecarProbeVariables(t.Spec.Sidecars, t.Spec.Params, t.Spec.Workspaces).ViaField("spec"))
+	errs = errs.Also(validateSidecarVariables(t.Spec.Sidecars, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(validateWorkspaceUsageVariables(t.Spec.Steps, t.Spec.Sidecars, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(validatePlatformImagesPinned(ctx, t).ViaField("spec"))
+	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(t.Spec.DisplayName, "params", t.Spec.Params.NameSet()).ViaField("displayName").ViaField("spec"))
+	if deduplicatesErrors(ctx) {
+		return deduplicateFieldErrors(errs)
+	}
 	return errs
 }
 
+// validatePlatformImagesPinned warns, when enabled by the "warn-unpinned-platform-images" policy, if a Task
+// declaring a target platform uses statically-known step or sidecar images that are not pinned by digest.
+// A tag may resolve to an image for a different platform, whereas a digest identifies a single manifest.
+func validatePlatformImagesPinned(ctx context.Context, t *Task) (errs *apis.FieldError) {
+	if !config.ValidationPolicyFromContextOrDefaults(ctx).WarnUnpinnedPlatformImages {
+		return nil
+	}
+	platform, ok := t.GetAnnotations()[TargetPlatformAnnotation]
+	if !ok {
+		return nil
+	}
+	warn := func(image string) *apis.FieldError {
+		if image == "" || strings.Contains(image, "$(") || strings.Contains(image, "@sha256:") {
+			return nil
+		}
+		return apis.ErrGeneric(fmt.Sprintf("image %q is not pinned by digest and may not resolve to an image for the target platform %q", image, platform), "image").At(apis.WarningLevel)
+	}
+	for i, s := range t.Spec.Steps {
+		errs = errs.Also(warn(s.Image).ViaFieldIndex("steps", i))
+	}
+	for i, s := range t.Spec.Sidecars {
+		errs = errs.Also(warn(s.Image).ViaFieldIndex("sidecars", i))
+	}
+	return errs
+}
+
+// ValidateAndDefault applies defaults to a copy of the Task and validates it, the same way
+// the webhook does. The receiver is not modified; the defaulted copy is returned along with
+// any validation errors.
+func (t *Task) ValidateAndDefault(ctx context.Context) (*Task, *apis.FieldError) {
+	defaulted := t.DeepCopy()
+	defaulted.SetDefaults(ctx)
+	return defaulted, defaulted.Validate(ctx)
+}
+
+// ValidateStructured validates the Task like Validate does, returning the errors and warnings
+// as a ValidationReport instead of an apis.FieldError.
+func (t *Task) ValidateStructured(ctx context.Context) ValidationReport {
+	return newValidationReport(t.Validate(ctx))
+}
+
+// ValidateTasks validates each of the Tasks like Validate does, and returns the errors and warnings of
+// the Tasks keyed by their name. The Tasks without any are not in the map, and an invalid Task does not
+// stop the validation of the others. The errors of Tasks sharing the same name are merged.
+func ValidateTasks(ctx context.Context, tasks []*Task) map[string]*apis.FieldError {
+	results := map[string]*apis.FieldError{}
+	for _, t := range tasks {
+		if t == nil {
+			continue
+		}
+		if errs := t.Validate(ctx); errs != nil {
+			results[t.Name] = results[t.Name].Also(errs)
+		}
+	}
+	return results
+}
+
+// RequiredAPIFields returns the lowest "enable-api-fields" level, "stable", "beta" or "alpha", that
+// the TaskSpec requires. The gated features are recorded while the spec is validated with ctx, and
+// the validation errors are not reported.
+func (ts *TaskSpec) RequiredAPIFields(ctx context.Context) string {
+	r := &config.APIFieldsRecorder{}
+	ctx = config.WithAPIFieldsRecorder(ctx, r)
+	_ = ts.Validate(ctx).Also(ValidateUsageOfDeclaredParameters(ctx, ts.Steps, ts.Params))
+	return r.Required()
+}
+
 // Validate implements apis.Validatable
 func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
-	if len(ts.Steps) == 0 {
-		errs = errs.Also(apis.ErrMissingField("steps"))
+	validators := ts.validators()
+	results := make([]*apis.FieldError, len(validators))
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableConcurrentValidation {
+		// The validators only read the TaskSpec and ctx, and each of them writes its own result,
+		// which are merged in order so that the errors are the same as when validating sequentially.
+		var wg sync.WaitGroup
+		for i, validate := range validators {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = validate(ctx)
+			}()
+		}
+		wg.Wait()
+	} else {
+		for i, validate := range validators {
+			results[i] = validate(ctx)
+		}
 	}
+	for _, err := range results {
+		errs = errs.Also(err)
+	}
+	if deduplicatesErrors(ctx) {
+		return deduplicateFieldErrors(errs)
+	}
+	return errs
+}
+
+// deduplicateErrorsKey is used as the key for enabling the deduplication of the validation errors in the context.
+type deduplicateErrorsKey struct{}
+
+// WithDeduplicatedErrors returns a context in which the validation of a Task or TaskSpec collapses the errors
+// with the same message that differ only by the value of the field they are reported on, e.g. a
+// reference to the same unknown variable in several fields, into a single error listing all the paths.
+// By default every error is reported with the value of its field.
+func WithDeduplicatedErrors(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deduplicateErrorsKey{}, true)
+}
 
-	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
-	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
-	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+func deduplicatesErrors(ctx context.Context) bool {
+	v, _ := ctx.Value(deduplicateErrorsKey{}).(bool)
+	return v
+}
+
+// fieldValueSuffixRegex matches the value of the field quoted at the end of an error message,
+// e.g. ` in "echo $(params.foo)"`.
+var fieldValueSuffixRegex = regexp.MustCompile(` in "(?:[^"\\]|\\.)*"$`)
+
+// deduplicateFieldErrors returns errs with the errors of the same level and details, whose messages
+// are the same once the quoted value of their field is dropped, collapsed into a single error
+// listing all their paths.
+func deduplicateFieldErrors(errs *apis.FieldError) *apis.FieldError {
+	if errs == nil {
+		return nil
+	}
+	type group struct {
+		err      apis.FieldError
+		messages sets.String
+	}
+	var keys []string
+	groups := map[string]*group{}
+	for _, e := range errs.WrappedErrors() {
+		message := fieldValueSuffixRegex.ReplaceAllString(e.Message, "")
+		key := fmt.Sprintf("%d-%s-%s", e.Level, message, e.Details)
+		g, ok := groups[key]
+		if !ok {
+			keys = append(keys, key)
+			groups[key] = &group{err: *e, messages: sets.NewString(e.Message)}
+			continue
+		}
+		g.messages.Insert(e.Message)
+		g.err.Paths = append(g.err.Paths, e.Paths...)
+		if g.messages.Len() > 1 {
+			g.err.Message = message
+		}
+	}
+	var deduplicated *apis.FieldError
+	for _, key := range keys {
+		e := groups[key].err
+		deduplicated = deduplicated.Also(&e)
+	}
+	return deduplicated
+}
+
+// validators returns the validations of the TaskSpec that do not depend on each other, in the
+// order their errors are reported.
+func (ts *TaskSpec) validators() []func(context.Context) *apis.FieldError {
+	return []func(context.Context) *apis.FieldError{
+		func(ctx context.Context) *apis.FieldError {
+			if len(ts.Steps) == 0 {
+				return apis.ErrMissingField("steps")
+			}
+			// The validations cross-checking the steps against the rest of the spec are skipped when
+			// there are no steps, so that only the missing steps are reported instead of their side effects.
+			return ts.validateSteps(ctx)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return ValidateVolumes(ts.Volumes).ViaField("volumes")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateDeclaredWorkspaces(ctx, ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateVolumesNamedAfterWorkspaces(ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateWorkspaceUsages(ctx, ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateWorkspaceOptionality(ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return SidecarList(ts.Sidecars).Validate(ctx).ViaField("sidecars")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return ValidateParameterTypes(ctx, ts.Params).ViaField("params")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateDefaultParamReferences(ts.Params).ViaField("params")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateDefaultParamCycles(ts.Params).ViaField("params")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return ValidateParameterVariables(ctx, ts.Steps, ts.Params)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateOnErrorParamEnums(ts.Steps, ts.Params)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateStepTemplateArrayUsage(ts.StepTemplate, ts.Params)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateSidecarArrayUsage(ts.Sidecars, ts.Params)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateTaskContextVariables(ctx, ts.Steps, ts.DisplayName)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateDeterministicContextVariables(ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateResults(ctx, ts.Results).ViaField("results")
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateResultsHaveSteps(ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateImageRegistries(ctx, ts)
+		},
+		func(ctx context.Context) *apis.FieldError {
+			return validateWorkingDirOverrides(ts)
+		},
+	}
+}
+
+// validateWorkingDirOverrides returns a warning for each step which sets a workingDir while the
+// StepTemplate sets one as well, since the workingDir of the step silently replaces the one of the
+// StepTemplate when they are merged.
+func validateWorkingDirOverrides(ts *TaskSpec) (errs *apis.FieldError) {
+	if ts.StepTemplate == nil || ts.StepTemplate.WorkingDir == "" {
+		return nil
+	}
+	for i, s := range ts.Steps {
+		if s.WorkingDir != "" && s.WorkingDir != ts.StepTemplate.WorkingDir {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workingDir %q overrides the workingDir %q of the stepTemplate", s.WorkingDir, ts.StepTemplate.WorkingDir), "workingDir").ViaFieldIndex("steps", i).At(apis.WarningLevel))
+		}
+	}
+	return errs
+}
+
+// validateResultsHaveSteps returns an error if the TaskSpec declares results but no steps, which
+// could never produce them. Steps are required as well, but this keeps the results coherent with
+// them should steps ever become optional.
+func validateResultsHaveSteps(ts *TaskSpec) *apis.FieldError {
+	if len(ts.Results) == 0 || len(ts.Steps) > 0 {
+		return nil
+	}
+	return apis.ErrGeneric("results are declared but there are no steps to produce them", "results")
+}
+
+// validateImageRegistries returns an error, when the "allowed-image-registries" policy is set, for each
+// statically-known step, sidecar or stepTemplate image pulled from a registry that is not allowed.
+func validateImageRegistries(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	allowed := config.ValidationPolicyFromContextOrDefaults(ctx).GetAllowedImageRegistries()
+	if len(allowed) == 0 {
+		return nil
+	}
+	check := func(image string) *apis.FieldError {
+		if image == "" || strings.Contains(image, "$(") {
+			return nil
+		}
+		if registry := imageRegistry(image); !slices.Contains(allowed, registry) {
+			return apis.ErrGeneric(fmt.Sprintf("registry %q of image %q is not allowed by the %q validation policy", registry, image, "allowed-image-registries"), "image")
+		}
+		return nil
+	}
+	for i, s := range ts.Steps {
+		errs = errs.Also(check(s.Image).ViaFieldIndex("steps", i))
+	}
+	for i, s := range ts.Sidecars {
+		errs = errs.Also(check(s.Image).ViaFieldIndex("sidecars", i))
+	}
+	if ts.StepTemplate != nil {
+		errs = errs.Also(check(ts.StepTemplate.Image).ViaField("stepTemplate"))
+	}
+	return errs
+}
+
+// imageRegistry returns the registry host of an image reference. Like the container runtimes, the
+// first component is only a registry if it contains a "." or a ":" or is "localhost", otherwise the
+// image is pulled from docker.io.
+func imageRegistry(image string) string {
+	host, _, found := strings.Cut(image, "/")
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		return "docker.io"
+	}
+	host = strings.ToLower(host)
+	if host == "index.docker.io" {
+		return "docker.io"
+	}
+	return host
+}
+
+// validateSteps validates the steps of the TaskSpec, merged with its StepTemplate, and their usage
+// of the results declared by the TaskSpec.
+func (ts *TaskSpec) validateSteps(ctx context.Context) (errs *apis.FieldError) {
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -88,47 +396,124 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(StepList(mergedSteps).Validate(ctx).ViaField("steps"))
-	errs = errs.Also(SidecarList(ts.Sidecars).Validate(ctx).ViaField("sidecars"))
-	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
-	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
-	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
-	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
-	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
-	return errs
+	errs = errs.Also(validateTotalScriptSize(ctx, ts.Steps))
+	// The result references are cross-checked on the merged steps, so that the references
+	// contributed by the stepTemplate are validated for each step they are merged into.
+	if mergedSteps == nil {
+		mergedSteps = ts.Steps
+	}
+	errs = errs.Also(validateTaskResultsVariables(ctx, mergedSteps, ts.Results))
+	errs = errs.Also(ts.validateTaskResultsProduced(mergedSteps))
+	return errs.Also(ts.validateContinueStepResultsConsumed(mergedSteps))
 }
 
 // ValidateUsageOfDeclaredParameters validates that all parameters referenced in the Task are declared by the Task.
 func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
-	_, _, objectParams := params.SortByType()
-	allParameterNames := sets.NewString(params.GetNames()...)
+	allParameterNames := params.NameSet()
 	errs = errs.Also(validateVariables(ctx, steps, "params", allParameterNames))
-	errs = errs.Also(validateObjectUsage(ctx, steps, objectParams))
+	errs = errs.Also(validateObjectUsage(ctx, steps, params))
 	errs = errs.Also(ValidateObjectParamsHaveProperties(ctx, params))
 	return errs
 }
 
-// ValidateObjectParamsHaveProperties returns an error if any declared object params are missing properties
+// validateStepTemplateUsageOfDeclaredParameters validates that all parameters referenced in the StepTemplate
+// are declared, and that object parameters are not referenced as a whole where this is prohibited.
+func validateStepTemplateUsageOfDeclaredParameters(ctx context.Context, stepTemplate *StepTemplate, params ParamSpecs) (errs *apis.FieldError) {
+	if stepTemplate == nil {
+		return nil
+	}
+	step := stepTemplate.toStep()
+	_, _, objectParams := params.SortByType()
+	errs = errs.Also(validateStepVariables(ctx, step, "params", params.NameSet()))
+	for _, p := range objectParams {
+		errs = errs.Also(validateStepVariables(ctx, step, "params\\."+p.Name, sets.StringKeySet(p.Properties)))
+	}
+	errs = errs.Also(validateStepObjectUsageAsWhole(ctx, step, "params", objectParams.NameSet()))
+	return errs.ViaField("stepTemplate")
+}
+
+// validateStepTemplateArrayUsage returns an error if the StepTemplate references array params in fields
+// where these references are prohibited.
+func validateStepTemplateArrayUsage(stepTemplate *StepTemplate, params ParamSpecs) *apis.FieldError {
+	if stepTemplate == nil {
+		return nil
+	}
+	_, arrayParams, _ := params.SortByType()
+	return validateStepArrayUsage(stepTemplate.toStep(), "params", arrayParams.NameSet()).ViaField("stepTemplate")
+}
+
+// validateSidecarArrayUsage returns an error if the Sidecars reference array params in fields where these
+// references are prohibited. Like for steps, a whole array can only expand into the command and the args.
+func validateSidecarArrayUsage(sidecars []Sidecar, params ParamSpecs) (errs *apis.FieldError) {
+	_, arrayParams, _ := params.SortByType()
+	arrayParamNames := arrayParams.NameSet()
+	for i, sc := range sidecars {
+		step := Step{
+			Name:         sc.Name,
+			Image:        sc.Image,
+			Command:      sc.Command,
+			Args:         sc.Args,
+			WorkingDir:   sc.WorkingDir,
+			Env:          sc.Env,
+			VolumeMounts: sc.VolumeMounts,
+			Script:       sc.Script,
+		}
+		errs = errs.Also(validateStepArrayUsage(step, "params", arrayParamNames).ViaFieldIndex("sidecars", i))
+	}
+	return errs
+}
+
+// ValidateObjectParamsHaveProperties returns an error if any declared object params are missing properties.
+// Object params with a default value are skipped, ParamSpec.ValidateType reports them with a more specific error.
 func ValidateObjectParamsHaveProperties(ctx context.Context, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
 	for _, p := range params {
-		if p.Type == ParamTypeObject && p.Properties == nil {
+		if p.Type == ParamTypeObject && p.Properties == nil && p.Default == nil {
 			errs = errs.Also(apis.ErrMissingField(p.Name + ".properties"))
 		}
 	}
 	return errs
 }
 
+// terminationMessageMaxSize is the maximum size in bytes of the termination message, which
+// is used to report results unless they are extracted from sidecar logs.
+const terminationMessageMaxSize = 4096
+
+// terminationMessageResultOverhead is the approximate size in bytes a result takes up in the
+// termination message in addition to its name and value.
+const terminationMessageResultOverhead = 32
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
 	}
+	if maxCount := config.ValidationPolicyFromContextOrDefaults(ctx).MaxResultCount; maxCount > 0 && len(results) > maxCount {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("a Task can declare at most %d results, got %d", maxCount, len(results)), ""))
+	}
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags == nil {
+		return errs
+	}
+	if cfg.FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodTerminationMessage {
+		// The values are only known at runtime, so the size is estimated from the names and the
+		// descriptions, which usually grow with the values they describe.
+		estimatedSize := 0
+		for _, result := range results {
+			estimatedSize += len(result.Name) + len(result.Description) + terminationMessageResultOverhead
+		}
+		if estimatedSize*10 >= terminationMessageMaxSize*8 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("the estimated size of the results, %d bytes, approaches the termination message limit of %d bytes", estimatedSize, terminationMessageMaxSize), "").At(apis.WarningLevel))
+		}
+	}
 	return errs
 }
 
+// validateDeclaredWorkspaces validates each declared workspace and makes sure that none of them use
 // a mount path which conflicts with any other declared workspaces, with the explicitly
-// declared volume mounts, or with the stepTemplate. The names must also be unique.
-func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
+// declared volume mounts, or with the stepTemplate. The names must also be unique, and there must
+// not be more workspaces than allowed by the "max-workspace-count" validation policy.
+func validateDeclaredWorkspaces(ctx context.Context, workspaces []WorkspaceDeclaration, steps []Step, stepTemplate *StepTemplate) (errs *apis.FieldError) {
 	mountPaths := sets.NewString()
 	for _, step := range steps {
 		for _, vm := range step.VolumeMounts {
@@ -143,6 +528,7 @@ func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 
 	wsNames := sets.NewString()
 	for idx, w := range workspaces {
+		errs = errs.Also(w.Validate(ctx).ViaIndex(idx))
 		// Workspace names must be unique
 		if wsNames.Has(w.Name) {
 			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace name %q must be unique", w.Name), "name").ViaIndex(idx))
@@ -156,6 +542,49 @@ func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 		}
 		mountPaths[mountPath] = struct{}{}
 	}
+	if maxCount := config.ValidationPolicyFromContextOrDefaults(ctx).MaxWorkspaceCount; maxCount > 0 && len(workspaces) > maxCount {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("a Task can declare at most %d workspaces, got %d", maxCount, len(workspaces)), ""))
+	}
+	return errs
+}
+
+// validateVolumesNamedAfterWorkspaces warns when a declared volume has the same name as a workspace and is
+// mounted at a different path than the workspace. The volume is distinct from the workspace, which may
+// surprise users expecting both to refer to the same source.
+func validateVolumesNamedAfterWorkspaces(ts *TaskSpec) (errs *apis.FieldError) {
+	workspaceMountPaths := map[string]string{}
+	for _, w := range ts.Workspaces {
+		workspaceMountPaths[w.Name] = filepath.Clean(w.GetMountPath())
+	}
+	volumes := sets.NewString()
+	for _, v := range ts.Volumes {
+		if _, ok := workspaceMountPaths[v.Name]; ok {
+			volumes.Insert(v.Name)
+		}
+	}
+	if volumes.Len() == 0 {
+		return nil
+	}
+	check := func(mounts []corev1.VolumeMount) (errs *apis.FieldError) {
+		for i, vm := range mounts {
+			if !volumes.Has(vm.Name) {
+				continue
+			}
+			if wsPath := workspaceMountPaths[vm.Name]; filepath.Clean(vm.MountPath) != wsPath {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volume %q has the same name as a workspace but is mounted at %q instead of the workspace mount path %q, they do not refer to the same source", vm.Name, vm.MountPath, wsPath), "mountPath").ViaFieldIndex("volumeMounts", i).At(apis.WarningLevel))
+			}
+		}
+		return errs
+	}
+	for i, s := range ts.Steps {
+		errs = errs.Also(check(s.VolumeMounts).ViaFieldIndex("steps", i))
+	}
+	for i, s := range ts.Sidecars {
+		errs = errs.Also(check(s.VolumeMounts).ViaFieldIndex("sidecars", i))
+	}
+	if ts.StepTemplate != nil {
+		errs = errs.Also(check(ts.StepTemplate.VolumeMounts).ViaField("stepTemplate"))
+	}
 	return errs
 }
 
@@ -199,6 +628,53 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// validateWorkspaceOptionality returns a warning for each Step whose usage of a workspace contradicts
+// its declared optionality: an optional workspace whose path is used without checking whether it is
+// bound, or a required workspace checked with $(workspaces.<name>.bound), which is always "true".
+func validateWorkspaceOptionality(ts *TaskSpec) (errs *apis.FieldError) {
+	for stepIdx, step := range ts.Steps {
+		values := append([]string{step.Script, step.WorkingDir}, step.Command...)
+		values = append(values, step.Args...)
+		for _, env := range step.Env {
+			values = append(values, env.Value)
+		}
+		for _, we := range step.When {
+			values = append(values, we.Input, we.CEL)
+			values = append(values, we.Values...)
+		}
+		uses := func(ref string) bool {
+			return slices.ContainsFunc(values, func(v string) bool { return strings.Contains(v, ref) })
+		}
+		for _, w := range ts.Workspaces {
+			bound := fmt.Sprintf("$(workspaces.%s.bound)", w.Name)
+			switch guarded := uses(bound); {
+			case w.Optional && !guarded && uses(fmt.Sprintf("$(workspaces.%s.path)", w.Name)):
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q is declared optional, but it is used without checking %s", w.Name, bound), "").At(apis.WarningLevel).ViaFieldIndex("steps", stepIdx))
+			case !w.Optional && guarded:
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q is checked with %s, but it is not declared optional so it is always bound", w.Name, bound), "").At(apis.WarningLevel).ViaFieldIndex("steps", stepIdx))
+			}
+		}
+	}
+	return errs
+}
+
+// validateWorkspaceUsageVariables returns an error if the mount path of any workspace used by
+// a Step or Sidecar references params that are not declared by the Task.
+func validateWorkspaceUsageVariables(steps []Step, sidecars []Sidecar, params ParamSpecs) (errs *apis.FieldError) {
+	paramNames := params.NameSet()
+	for stepIdx, step := range steps {
+		for workspaceIdx, w := range step.Workspaces {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(w.MountPath, "params", paramNames).ViaField("mountPath").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(stepIdx).ViaField("steps"))
+		}
+	}
+	for sidecarIdx, sidecar := range sidecars {
+		for workspaceIdx, w := range sidecar.Workspaces {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(w.MountPath, "params", paramNames).ViaField("mountPath").ViaIndex(workspaceIdx).ViaField("workspaces").ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+	}
+	return errs
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no duplicate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -217,22 +693,116 @@ func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 func (l StepList) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Task must not have duplicate step names.
 	names := sets.NewString()
+	// ran holds the steps running before the current one, by name.
+	ran := map[string]Step{}
+	policy := config.ValidationPolicyFromContextOrDefaults(ctx)
 	for idx, s := range l {
+		if policy.MaxStepScriptSize > 0 && len(s.Script) > policy.MaxStepScriptSize {
+			errs = errs.Also((&apis.FieldError{
+				Message: fmt.Sprintf("script size of %d bytes exceeds the maximum of %d bytes", len(s.Script), policy.MaxStepScriptSize),
+				Paths:   []string{"script"},
+				Details: "consider mounting the script from a workspace instead of declaring it inline",
+			}).ViaIndex(idx))
+		}
 		// names cannot be duplicated - checking that Step names are unique
 		if s.Name != "" {
 			if names.Has(s.Name) {
 				errs = errs.Also(apis.ErrMultipleOneOf("name").ViaIndex(idx))
 			}
 			names.Insert(s.Name)
+		} else if policy.RequireStepNames {
+			// generated names depend on the position of the step, so they change when steps are added or reordered
+			errs = errs.Also(apis.ErrMissingField("name").ViaIndex(idx))
 		}
 
 		errs = errs.Also(s.Validate(ctx).ViaIndex(idx))
+		errs = errs.Also(validateMalformedStepResultReferences(s.Script).ViaIndex(idx))
+		errs = errs.Also(validateResourceRequestsWithinLimits(s.ComputeResources).ViaField("computeResources").ViaIndex(idx))
+		if policy.RequireStepResourceLimits {
+			errs = errs.Also(validateResourceLimitsDeclared(s.ComputeResources).ViaField("computeResources").ViaIndex(idx))
+		}
+		if policy.DenyPrivilegeEscalation {
+			errs = errs.Also(validateNoPrivilegeEscalation(s.SecurityContext).ViaIndex(idx))
+		}
 		if s.Results != nil {
 			errs = errs.Also(ValidateStepResultsVariables(ctx, s.Results, s.Script).ViaIndex(idx))
 			errs = errs.Also(ValidateStepResults(ctx, s.Results).ViaIndex(idx).ViaField("results"))
+			errs = errs.Also(validateStepResultsWritten(s).ViaIndex(idx))
+			errs = errs.Also(validateResultsDirNotShadowed(s).ViaIndex(idx))
 		}
 		if len(s.When) > 0 {
 			errs = errs.Also(s.When.validate(ctx).ViaIndex(idx))
+			errs = errs.Also(s.When.validateNoDuplicates().ViaIndex(idx))
+			errs = errs.Also(s.When.validateStepResultsRunBefore(ran).ViaIndex(idx))
+		}
+		ran[s.Name] = s
+	}
+	return errs
+}
+
+// validateTotalScriptSize returns an error if the combined size of the step scripts exceeds
+// the "max-total-script-size" validation policy.
+func validateTotalScriptSize(ctx context.Context, steps []Step) *apis.FieldError {
+	maxSize := config.ValidationPolicyFromContextOrDefaults(ctx).MaxTotalScriptSize
+	if maxSize == 0 {
+		return nil
+	}
+	totalSize := 0
+	for _, s := range steps {
+		totalSize += len(s.Script)
+	}
+	if totalSize <= maxSize {
+		return nil
+	}
+	return &apis.FieldError{
+		Message: fmt.Sprintf("combined script size of %d bytes exceeds the maximum of %d bytes", totalSize, maxSize),
+		Paths:   []string{"steps"},
+		Details: "consider mounting the scripts from a workspace instead of declaring them inline",
+	}
+}
+
+// validateNoPrivilegeEscalation returns an error if the SecurityContext sets allowPrivilegeEscalation to
+// true. Leaving it unset is allowed, so that it can be defaulted.
+func validateNoPrivilegeEscalation(sc *corev1.SecurityContext) *apis.FieldError {
+	if sc == nil || sc.AllowPrivilegeEscalation == nil || !*sc.AllowPrivilegeEscalation {
+		return nil
+	}
+	return &apis.FieldError{
+		Message: "invalid value: true",
+		Paths:   []string{"securityContext.allowPrivilegeEscalation"},
+		Details: fmt.Sprintf("validation policy %q requires allowPrivilegeEscalation to be false or unset", "deny-privilege-escalation"),
+	}
+}
+
+// validateResourceLimitsDeclared returns an error listing the CPU and memory limits which are not declared.
+func validateResourceLimitsDeclared(resources corev1.ResourceRequirements) *apis.FieldError {
+	var missing []string
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		if _, ok := resources.Limits[name]; !ok {
+			missing = append(missing, "limits."+string(name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &apis.FieldError{
+		Message: "missing field(s)",
+		Paths:   missing,
+		Details: fmt.Sprintf("validation policy %q requires steps to declare CPU and memory limits", "require-step-resource-limits"),
+	}
+}
+
+// validateResourceRequestsWithinLimits returns an error for each resource whose request exceeds its limit.
+func validateResourceRequestsWithinLimits(resources corev1.ResourceRequirements) (errs *apis.FieldError) {
+	names := make([]string, 0, len(resources.Limits))
+	for name := range resources.Limits {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		limit := resources.Limits[corev1.ResourceName(name)]
+		if request, ok := resources.Requests[corev1.ResourceName(name)]; ok && request.Cmp(limit) > 0 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s request %s exceeds the limit %s", name, request.String(), limit.String()), ""))
 		}
 	}
 	return errs
@@ -246,6 +816,66 @@ func ValidateStepResults(ctx context.Context, results []StepResult) (errs *apis.
 	return errs
 }
 
+// validateStepResultsWritten returns a warning for each StepResult that is not referenced in the step's
+// script, command, args or env values, since such a result is likely never written. This is only a
+// heuristic as the result may still be written by the entrypoint of the image.
+func validateStepResultsWritten(s Step) (errs *apis.FieldError) {
+	if s.Ref != nil {
+		return nil
+	}
+	fields := append([]string{s.Script}, s.Command...)
+	fields = append(fields, s.Args...)
+	for _, env := range s.Env {
+		fields = append(fields, env.Value)
+	}
+	referenced := sets.NewString()
+	for _, field := range fields {
+		for _, prefix := range []string{"step.results", "results"} {
+			if vs, present, _ := substitution.ExtractVariablesFromString(field, prefix); present {
+				referenced.Insert(vs...)
+			}
+		}
+	}
+	for i, r := range s.Results {
+		if !referenced.Has(r.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q is not referenced by the step and may never be written", r.Name), "").ViaFieldIndex("results", i).At(apis.WarningLevel))
+		}
+	}
+	return errs
+}
+
+// validateResultsDirNotShadowed returns an error if a volumeMount or workspace of a Step declaring
+// results is mounted at a parent directory of the paths the results are written to, since the mount
+// would hide them. Mounts under /tekton/ are rejected by Step.Validate already.
+func validateResultsDirNotShadowed(s Step) (errs *apis.FieldError) {
+	shadows := func(mountPath string) (string, bool) {
+		mountPath = filepath.Clean(mountPath)
+		if strings.HasPrefix(mountPath, "/tekton/") {
+			return "", false
+		}
+		for _, dir := range []string{pipeline.DefaultResultPath, pipeline.StepsDir} {
+			if mountPath == "/" || strings.HasPrefix(dir, mountPath+"/") {
+				return dir, true
+			}
+		}
+		return "", false
+	}
+	for i, vm := range s.VolumeMounts {
+		if dir, ok := shadows(vm.MountPath); ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("volumeMount %q mounted at %q shadows the results directory %q", vm.Name, vm.MountPath, dir), "mountPath").ViaFieldIndex("volumeMounts", i))
+		}
+	}
+	for i, w := range s.Workspaces {
+		if w.MountPath == "" {
+			continue
+		}
+		if dir, ok := shadows(w.MountPath); ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace %q mounted at %q shadows the results directory %q", w.Name, w.MountPath, dir), "mountPath").ViaFieldIndex("workspaces", i))
+		}
+	}
+	return errs
+}
+
 // ValidateStepResultsVariables validates if the StepResults referenced in step script are defined in step's results.
 func ValidateStepResultsVariables(ctx context.Context, results []StepResult, script string) (errs *apis.FieldError) {
 	resultsNames := sets.NewString()
@@ -257,36 +887,122 @@ func ValidateStepResultsVariables(ctx context.Context, results []StepResult, scr
 	return errs
 }
 
+// validateMalformedStepResultReferences returns an error for each $(steps.results.<name>) reference in
+// the script, pointing to $(step.results.<name>.path) for the results of the current step and to
+// $(steps.<stepName>.results.<name>) for the results of an earlier step.
+func validateMalformedStepResultReferences(script string) (errs *apis.FieldError) {
+	for _, m := range malformedStepResultRegex.FindAllStringSubmatch(script, -1) {
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("%s is not a valid step result reference", m[0]),
+			Paths:   []string{"script"},
+			Details: fmt.Sprintf("use $(step.results.%s.path) to write the result of the current step, or $(steps.<stepName>.results.%s) to read the result of an earlier step", m[1], m[1]),
+		})
+	}
+	return errs
+}
+
 func (l SidecarList) Validate(ctx context.Context) (errs *apis.FieldError) {
-	for _, sc := range l {
+	denyPrivilegeEscalation := config.ValidationPolicyFromContextOrDefaults(ctx).DenyPrivilegeEscalation
+	for i, sc := range l {
 		errs = errs.Also(sc.Validate(ctx))
+		if denyPrivilegeEscalation {
+			errs = errs.Also(validateNoPrivilegeEscalation(sc.SecurityContext).ViaIndex(i))
+		}
+	}
+	return errs.Also(l.validatePortsUnique())
+}
+
+// validatePortsUnique returns an error if a port is exposed more than once. The containers of the
+// TaskRun pod share the same network namespace, so the ports must be unique across all the Sidecars.
+func (l SidecarList) validatePortsUnique() (errs *apis.FieldError) {
+	exposedBy := map[corev1.ContainerPort]string{}
+	for i, sc := range l {
+		for j, p := range sc.Ports {
+			key := corev1.ContainerPort{ContainerPort: p.ContainerPort, Protocol: p.Protocol}
+			if key.Protocol == "" {
+				key.Protocol = corev1.ProtocolTCP
+			}
+			if name, ok := exposedBy[key]; ok {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("port %d/%s is already exposed by sidecar %q", key.ContainerPort, key.Protocol, name), "").ViaFieldIndex("ports", j).ViaIndex(i))
+				continue
+			}
+			exposedBy[key] = sc.Name
+		}
 	}
 	return errs
 }
 
 // ValidateParameterTypes validates all the types within a slice of ParamSpecs
 func ValidateParameterTypes(ctx context.Context, params []ParamSpec) (errs *apis.FieldError) {
+	policy := config.ValidationPolicyFromContextOrDefaults(ctx)
+	var allowedTypes []string
+	if policy.AllowedParamTypes != "" {
+		allowedTypes = strings.Split(policy.AllowedParamTypes, ",")
+	}
 	for _, p := range params {
+		errs = errs.Also(validateDescription(ctx, p.Description).ViaField(p.Name))
+		errs = errs.Also(warnDescriptionVariableReferences(p.Description).ViaField(p.Name))
+		if p.Type != "" && allowedTypes != nil && !slices.Contains(allowedTypes, string(p.Type)) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param type %q is not allowed, allowed types are %q", p.Type, policy.AllowedParamTypes), p.Name+".type"))
+			continue
+		}
+		if p.Type == "" && policy.RequireExplicitParamTypes {
+			errs = errs.Also(&apis.FieldError{
+				Message: "missing field(s)",
+				Paths:   []string{p.Name + ".type"},
+				Details: fmt.Sprintf("validation policy %q requires params to explicitly declare their type", "require-explicit-param-types"),
+			})
+			continue
+		}
 		errs = errs.Also(p.ValidateType(ctx))
 	}
 	return errs
 }
 
-// ValidateType checks that the type of a ParamSpec is allowed and its default value matches that type
+// ValidateType checks that the type of a ParamSpec is allowed and its default value matches that type.
+// A param without a default, which must be provided by the run, is distinct from a param whose default
+// is empty, e.g. an array param defaulting to [], which is a valid default of the declared type.
 func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	// Ensure param has a valid type.
-	validType := false
-	for _, allowedType := range AllParamTypes {
-		if p.Type == allowedType {
-			validType = true
+	if !IsValidParamType(p.Type) {
+		return apis.ErrInvalidValue(p.Type, p.Name+".type")
+	}
+
+	if p.Format != "" {
+		if errs := p.validateFormat(ctx); errs != nil {
+			return errs
 		}
 	}
-	if !validType {
-		return apis.ErrInvalidValue(p.Type, p.Name+".type")
+
+	// Integer params are an alpha feature and will fail validation if they are declared
+	// when the enable-api-fields feature gate is not "alpha".
+	if p.Type == ParamTypeInteger {
+		return config.ValidateEnabledAPIFields(ctx, "integer param type", config.AlphaAPIFields).ViaField(p.Name + ".type").
+			Also(p.validateIntegerDefault())
+	}
+
+	// Without properties, the keys of an object default cannot be validated.
+	if p.Type == ParamTypeObject && p.HasDefault() && p.Properties == nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("object param %q declares a default value but no properties, so the keys of the default value cannot be validated", p.Name),
+			Paths:   []string{p.Name + ".properties"},
+			Details: "declare the keys of the object in properties",
+		}
+	}
+
+	if p.Type == ParamTypeObject && p.Default.isJSONObjectString() {
+		return p.validateObjectDefaultString().Also(p.ValidateObjectType(ctx))
+	}
+
+	// An array param declaring properties is an array of objects, whose elements share the properties.
+	if p.Type == ParamTypeArray && p.Properties != nil {
+		return config.ValidateEnabledAPIFields(ctx, "array of objects param", config.AlphaAPIFields).ViaField(p.Name + ".properties").
+			Also(p.ValidateObjectType(ctx)).
+			Also(p.validateArrayOfObjectsDefault())
 	}
 
 	// If a default value is provided, ensure its type matches param's declared type.
-	if (p.Default != nil) && (p.Default.Type != p.Type) {
+	if p.HasDefault() && p.Default.Type != p.Type {
 		return &apis.FieldError{
 			Message: fmt.Sprintf(
 				"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
@@ -298,7 +1014,184 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	}
 
 	// Check object type and its PropertySpec type
-	return p.ValidateObjectType(ctx)
+	errs := p.ValidateObjectType(ctx)
+	if p.Type == ParamTypeObject && p.HasDefault() {
+		// Defaulting converts an object default supplied as a JSON string to its structured form.
+		errs = errs.Also(p.validateObjectDefaultKeys(p.Default.ObjectVal))
+	}
+	return errs
+}
+
+// validateDefaultParamReferences returns a warning for each param whose default value references
+// other params, since params are not substituted into the defaults of other Task params.
+// StepActions are not checked as they do resolve references between param defaults.
+func validateDefaultParamReferences(params []ParamSpec) (errs *apis.FieldError) {
+	for _, p := range params {
+		errs = errs.Also(p.validateDefaultParamReferences())
+	}
+	return errs
+}
+
+func (p ParamSpec) validateDefaultParamReferences() *apis.FieldError {
+	for _, v := range p.defaultValues() {
+		if _, present, _ := substitution.ExtractVariablesFromString(v, "params"); present {
+			return apis.ErrGeneric(fmt.Sprintf("default value %q references another param, which will not be resolved", v), p.Name+".default").At(apis.WarningLevel)
+		}
+	}
+	return nil
+}
+
+// defaultValues returns the strings making up the default value of the param, if any. The elements
+// of an array default keep the order of the spec, and the values of an object default are sorted by
+// key, so that the messages built from them do not depend on the map iteration order.
+func (p ParamSpec) defaultValues() []string {
+	if p.Default == nil {
+		return nil
+	}
+	values := append([]string{p.Default.StringVal}, p.Default.ArrayVal...)
+	for _, k := range sets.StringKeySet(p.Default.ObjectVal).List() {
+		values = append(values, p.Default.ObjectVal[k])
+	}
+	return values
+}
+
+// validateDefaultParamCycles returns an error for each cycle of params whose default values
+// reference each other, including a param referencing itself, since such defaults can never be resolved.
+func validateDefaultParamCycles(params []ParamSpec) (errs *apis.FieldError) {
+	refs := make(map[string][]string, len(params))
+	for _, p := range params {
+		names := sets.NewString()
+		for _, v := range p.defaultValues() {
+			vars, _, _ := substitution.ExtractVariablesFromString(v, "params")
+			for _, name := range vars {
+				names.Insert(strings.SplitN(name, "[", 2)[0])
+			}
+		}
+		refs[p.Name] = names.List()
+	}
+	// visiting holds the params on the current path, visited the params whose references were all checked.
+	visiting, visited := sets.NewString(), sets.NewString()
+	var path []string
+	var visit func(name string)
+	visit = func(name string) {
+		visiting.Insert(name)
+		path = append(path, name)
+		for _, ref := range refs[name] {
+			if _, declared := refs[ref]; !declared || visited.Has(ref) {
+				continue
+			}
+			if !visiting.Has(ref) {
+				visit(ref)
+				continue
+			}
+			cycle := append(slices.Clone(path[slices.Index(path, ref):]), ref)
+			quoted := make([]string, len(cycle))
+			for i, n := range cycle {
+				quoted[i] = strconv.Quote(n)
+			}
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default values of params form a cycle: %s", strings.Join(quoted, " -> ")), ref+".default"))
+		}
+		path = path[:len(path)-1]
+		visiting.Delete(name)
+		visited.Insert(name)
+	}
+	for _, p := range params {
+		if !visited.Has(p.Name) {
+			visit(p.Name)
+		}
+	}
+	return errs
+}
+
+// validateIntegerDefault checks that the default value of an integer param, if any,
+// is a string holding a whole number.
+func (p ParamSpec) validateIntegerDefault() *apis.FieldError {
+	if p.Default == nil {
+		return nil
+	}
+	if p.Default.Type != ParamTypeString {
+		return &apis.FieldError{
+			Message: fmt.Sprintf(
+				"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
+			Paths: []string{
+				p.Name + ".type",
+				p.Name + ".default.type",
+			},
+		}
+	}
+	if _, err := strconv.ParseInt(p.Default.StringVal, 10, 64); err != nil {
+		if f, ferr := strconv.ParseFloat(p.Default.StringVal, 64); ferr == nil && f != math.Trunc(f) {
+			return apis.ErrGeneric(fmt.Sprintf("default value %q of integer param must not have a fractional part", p.Default.StringVal), p.Name+".default")
+		}
+		return apis.ErrGeneric(fmt.Sprintf("default value %q of integer param is not a valid integer", p.Default.StringVal), p.Name+".default")
+	}
+	return nil
+}
+
+// validateObjectDefaultString checks that the default value of an object param supplied as a JSON
+// string is a valid JSON object whose keys are declared once, and in the param's properties.
+func (p ParamSpec) validateObjectDefaultString() (errs *apis.FieldError) {
+	var m map[string]string
+	if err := json.Unmarshal([]byte(p.Default.StringVal), &m); err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("default value %q of object param is not a valid JSON object", p.Default.StringVal),
+			Paths:   []string{p.Name + ".default"},
+			Details: err.Error(),
+		}
+	}
+	for _, k := range duplicateJSONObjectKeys(p.Default.StringVal) {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value key %q is declared more than once in the JSON object of object param", k), p.Name+".default"))
+	}
+	return errs.Also(p.validateObjectDefaultKeys(m))
+}
+
+// validateObjectDefaultKeys checks that the keys of the default value of an object param are
+// declared in the param's properties.
+func (p ParamSpec) validateObjectDefaultKeys(m map[string]string) (errs *apis.FieldError) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, ok := p.Properties[k]; !ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value key %q is not declared in the properties of object param", k), p.Name+".default"))
+		}
+	}
+	return errs
+}
+
+// validateArrayOfObjectsDefault checks that each element of the default value of an array of objects
+// param is a JSON object with exactly the keys declared in the param's properties.
+func (p ParamSpec) validateArrayOfObjectsDefault() (errs *apis.FieldError) {
+	if !p.HasDefault() {
+		return nil
+	}
+	if p.Default.Type != ParamTypeArray {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
+			Paths:   []string{p.Name + ".type", p.Name + ".default.type"},
+		}
+	}
+	for i, v := range p.Default.ArrayVal {
+		path := fmt.Sprintf("%s.default[%d]", p.Name, i)
+		var m map[string]string
+		if err := json.Unmarshal([]byte(v), &m); err != nil || m == nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value element %q of array of objects param is not a valid JSON object", v), path))
+			continue
+		}
+		for _, k := range sets.StringKeySet(p.Properties).List() {
+			if _, ok := m[k]; !ok {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value element is missing the key %q declared in the properties of array of objects param", k), path))
+			}
+		}
+		for _, k := range sets.StringKeySet(m).List() {
+			if _, ok := p.Properties[k]; !ok {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("default value element key %q is not declared in the properties of array of objects param", k), path))
+			}
+		}
+	}
+	return errs
 }
 
 // ValidateObjectType checks that object type parameter does not miss the
@@ -327,15 +1220,160 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 	var errs *apis.FieldError
 	errs = errs.Also(params.ValidateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamPatterns(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamItemCounts(ctx).ViaField("params"))
 	stringParams, arrayParams, objectParams := params.SortByType()
-	stringParameterNames := sets.NewString(stringParams.GetNames()...)
-	arrayParameterNames := sets.NewString(arrayParams.GetNames()...)
+	stringParameterNames := stringParams.NameSet()
+	arrayParameterNames := arrayParams.NameSet()
 	errs = errs.Also(ValidateNameFormat(stringParameterNames.Insert(arrayParameterNames.List()...), objectParams))
+	errs = errs.Also(validateParamNamePattern(ctx, params))
+	errs = errs.Also(validateAmbiguousParamReferences(steps, params))
+	errs = errs.Also(validateArrayElementKeyUsage(steps, arrayParams))
+	errs = errs.Also(validateArrayWholeAndStarUsage(steps, arrayParameterNames))
 	return errs.Also(validateArrayUsage(steps, "params", arrayParameterNames))
 }
 
-// validateTaskContextVariables returns an error if any Steps reference context variables that don't exist.
-func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.FieldError {
+// arrayElementKeyRegex matches the references to a key of an element of an array of objects param,
+// e.g. $(params.items[0].name).
+var arrayElementKeyRegex = regexp.MustCompile(`\$\(params\.([_a-zA-Z0-9-]+)\[([0-9]+)\]\.([_a-zA-Z0-9.-]+)\)`)
+
+// validateArrayElementKeyUsage returns an error if the Steps reference a key of an element of an array
+// param, e.g. $(params.items[0].name), which is not declared in the properties of the param. The
+// elements of an array param without properties are strings, which have no keys.
+func validateArrayElementKeyUsage(steps []Step, arrayParams ParamSpecs) (errs *apis.FieldError) {
+	arrays := map[string]ParamSpec{}
+	for _, p := range arrayParams {
+		arrays[p.Name] = p
+	}
+	for idx, step := range steps {
+		for _, v := range stepVariableValues(step) {
+			for _, match := range arrayElementKeyRegex.FindAllStringSubmatch(v, -1) {
+				p, ok := arrays[match[1]]
+				switch {
+				case !ok:
+					continue
+				case p.Properties == nil:
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s references the key %q of an element of array param %q, which declares no properties", match[0], match[3], p.Name), "").ViaFieldIndex("steps", idx))
+				case !sets.StringKeySet(p.Properties).Has(match[3]):
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s references the key %q, which is not declared in the properties of array of objects param %q", match[0], match[3], p.Name), "").ViaFieldIndex("steps", idx))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateArrayWholeAndStarUsage returns an error if an array param is referenced both as a whole, e.g.
+// $(params.flags), and with the star notation, e.g. $(params.flags[*]), since the two references expand
+// the same way and mixing them makes the intent unclear. Only the command and args are checked, the other
+// fields already reject whole array references. The error is reported on the Steps with the whole references.
+func validateArrayWholeAndStarUsage(steps []Step, arrayParamNames sets.String) (errs *apis.FieldError) {
+	starUsed := sets.NewString()
+	wholeUsed := make([]sets.String, len(steps))
+	for idx, step := range steps {
+		wholeUsed[idx] = sets.NewString()
+		for _, v := range append(append([]string{}, step.Command...), step.Args...) {
+			expressions, _ := substitution.ExtractVariableExpressions(v, "params")
+			for _, expr := range expressions {
+				vars, _, _ := substitution.ExtractVariablesFromString(expr, "params")
+				if len(vars) != 1 {
+					continue
+				}
+				name, star := strings.CutSuffix(vars[0], "[*]")
+				switch {
+				case !arrayParamNames.Has(name):
+				case star:
+					starUsed.Insert(name)
+				default:
+					wholeUsed[idx].Insert(name)
+				}
+			}
+		}
+	}
+	for idx, names := range wholeUsed {
+		for _, name := range names.Intersection(starUsed).List() {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("array param %q is referenced both as $(params.%s) and as $(params.%s[*]), reference it consistently as $(params.%s[*])", name, name, name, name), "").ViaFieldIndex("steps", idx))
+		}
+	}
+	return errs
+}
+
+// validateAmbiguousParamReferences warns when the Steps reference a param whose name contains a dot with
+// the dot notation, e.g. $(params.config.key), while the part of the name before the dot is declared as a
+// param as well, since the reference reads like the key "key" of the param "config".
+func validateAmbiguousParamReferences(steps []Step, params ParamSpecs) (errs *apis.FieldError) {
+	names := params.NameSet()
+	ambiguous := sets.NewString()
+	for _, ref := range extractParamRefsFromSteps(steps) {
+		expressions, _ := substitution.ExtractVariableExpressions(ref, "params")
+		for _, expr := range expressions {
+			name, ok := strings.CutPrefix(strings.TrimSuffix(expr, ")"), "$(params.")
+			if !ok || strings.Contains(name, "[") {
+				continue
+			}
+			if base, _, found := strings.Cut(name, "."); found && names.Has(name) && names.Has(base) {
+				ambiguous.Insert(name)
+			}
+		}
+	}
+	for _, name := range ambiguous.List() {
+		base, key, _ := strings.Cut(name, ".")
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("$(params.%s) may refer to the param %q or to the key %q of the param %q, reference it as $(params[%q]) instead", name, name, key, base, name), paramKeyPath(name, "name")).At(apis.WarningLevel))
+	}
+	return errs
+}
+
+// validateOnErrorParamEnums returns an error if the onError of a Step is a reference to a param
+// declaring an enum that allows values other than "continue" and "stopAndFail".
+func validateOnErrorParamEnums(steps []Step, params ParamSpecs) (errs *apis.FieldError) {
+	for idx, step := range steps {
+		if !isParamRefs(string(step.OnError)) {
+			continue
+		}
+		vars, _, _ := substitution.ExtractVariablesFromString(string(step.OnError), "params")
+		if len(vars) != 1 {
+			continue
+		}
+		for _, p := range params {
+			if p.Name != vars[0] {
+				continue
+			}
+			for _, v := range p.Enum {
+				if OnErrorType(v) != Continue && OnErrorType(v) != StopAndFail {
+					errs = errs.Also((&apis.FieldError{
+						Message: fmt.Sprintf("param %q allows the value %q, which is not a valid onError value", p.Name, v),
+						Paths:   []string{"onError"},
+						Details: "Task step onError must be either \"continue\" or \"stopAndFail\"",
+					}).ViaFieldIndex("steps", idx))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// UsedContextVariables returns the context variables referenced by the steps and the displayName
+// of the TaskSpec, e.g. "context.taskRun.name" or "context.task.retry-count". Use List() on the
+// returned set to get them in a deterministic order.
+func (ts *TaskSpec) UsedContextVariables() sets.String {
+	values := []string{ts.DisplayName}
+	for _, step := range ts.Steps {
+		values = append(values, stepVariableValues(step)...)
+	}
+	used := sets.NewString()
+	for _, prefix := range []string{"context.taskRun", "context.task"} {
+		for _, v := range values {
+			names, _, _ := substitution.ExtractVariablesFromString(v, regexp.QuoteMeta(prefix))
+			for _, name := range names {
+				used.Insert(prefix + "." + name)
+			}
+		}
+	}
+	return used
+}
+
+// validateTaskContextVariables returns an error if any Steps or the displayName reference context variables that don't exist.
+func validateTaskContextVariables(ctx context.Context, steps []Step, displayName string) *apis.FieldError {
 	taskRunContextNames := sets.NewString().Insert(
 		"name",
 		"namespace",
@@ -346,7 +1384,45 @@ func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.Field
 		"retry-count",
 	)
 	errs := validateVariables(ctx, steps, "context\\.taskRun", taskRunContextNames)
-	return errs.Also(validateVariables(ctx, steps, "context\\.task", taskContextNames))
+	errs = errs.Also(validateVariables(ctx, steps, "context\\.task", taskContextNames))
+	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(displayName, "context\\.taskRun", taskRunContextNames).ViaField("displayName"))
+	return errs.Also(substitution.ValidateNoReferencesToUnknownVariables(displayName, "context\\.task", taskContextNames).ViaField("displayName"))
+}
+
+// validateDeterministicContextVariables returns a warning if workspace mount paths or result declarations
+// reference taskRun context variables that change on every run, since these make the Task non-deterministic.
+func validateDeterministicContextVariables(ts *TaskSpec) (errs *apis.FieldError) {
+	for idx, w := range ts.Workspaces {
+		errs = errs.Also(warnIfNonDeterministicContextReferenced(w.MountPath).ViaField("mountPath").ViaFieldIndex("workspaces", idx))
+	}
+	for idx, r := range ts.Results {
+		if r.Value == nil {
+			continue
+		}
+		errs = errs.Also(warnIfNonDeterministicContextReferenced(r.Value.StringVal).ViaField("value").ViaFieldIndex("results", idx))
+		for i, v := range r.Value.ArrayVal {
+			errs = errs.Also(warnIfNonDeterministicContextReferenced(v).ViaFieldIndex("value", i).ViaFieldIndex("results", idx))
+		}
+		for _, k := range sets.StringKeySet(r.Value.ObjectVal).List() {
+			errs = errs.Also(warnIfNonDeterministicContextReferenced(r.Value.ObjectVal[k]).ViaFieldKey("value", k).ViaFieldIndex("results", idx))
+		}
+	}
+	return errs
+}
+
+// warnIfNonDeterministicContextReferenced returns a warning if value references $(context.taskRun.name) or $(context.taskRun.uid).
+func warnIfNonDeterministicContextReferenced(value string) *apis.FieldError {
+	nonDeterministicNames := sets.NewString("name", "uid")
+	vs, present, _ := substitution.ExtractVariablesFromString(value, "context\\.taskRun")
+	if !present {
+		return nil
+	}
+	for _, v := range vs {
+		if nonDeterministicNames.Has(v) {
+			return apis.ErrGeneric(fmt.Sprintf("non-deterministic variable $(context.taskRun.%s) used in %q", v, value), "").At(apis.WarningLevel)
+		}
+	}
+	return nil
 }
 
 // validateTaskResultsVariables validates if the results referenced in step script are defined in task results
@@ -361,10 +1437,156 @@ func validateTaskResultsVariables(ctx context.Context, steps []Step, results []T
 	return errs
 }
 
-// validateObjectUsage validates the usage of individual attributes of an object param and the usage of the entire object
+// validateTaskResultsProduced checks that every Task result is produced by the steps. A Task result is
+// produced by the step result its value references, or otherwise by the steps writing to its path, i.e.
+// referencing $(results.<name>.path), or the literal path under /tekton/results, in their script, command,
+// args or env. An error is returned for a
+// value referencing a step, or a result of a step, which is not declared. A warning is returned for a
+// result without a value which no step writes to, or which is only written by steps guarded by when
+// expressions, since these may be skipped. Steps referencing a StepAction cannot be inspected, so no
+// warning is returned when the Task has any.
+func (ts *TaskSpec) validateTaskResultsProduced(steps []Step) (errs *apis.FieldError) {
+	hasStepActions := slices.ContainsFunc(steps, func(s Step) bool { return s.Ref != nil })
+	for idx, r := range ts.Results {
+		if values := taskResultValues(r); len(values) > 0 {
+			for _, v := range values {
+				errs = errs.Also(ts.validateTaskResultProducer(r.Name, v).ViaField("value").ViaFieldIndex("results", idx))
+			}
+			continue
+		}
+		if hasStepActions {
+			continue
+		}
+		var stepWriters []Step
+		for _, step := range steps {
+			if writesTaskResult(step, r.Name) {
+				stepWriters = append(stepWriters, step)
+			}
+		}
+		if len(stepWriters) == 0 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("task result %q is not written by any step and may never be produced", r.Name), "").At(apis.WarningLevel).ViaFieldIndex("results", idx))
+			continue
+		}
+		if !slices.ContainsFunc(stepWriters, func(s Step) bool { return len(s.When) == 0 }) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("task result %q is only written by steps guarded by when expressions and may not be produced", r.Name), "").At(apis.WarningLevel).ViaFieldIndex("results", idx))
+		}
+	}
+	return errs
+}
+
+// validateTaskResultProducer returns an error if the value of a Task result references a step,
+// or a result of a step, which is not declared.
+func (ts *TaskSpec) validateTaskResultProducer(name, value string) *apis.FieldError {
+	stepName, resultName, err := ExtractStepResultName(value)
+	if err != nil {
+		return nil
+	}
+	step, _, found := ts.StepByName(stepName)
+	if !found {
+		return apis.ErrGeneric(fmt.Sprintf("task result %q references step %q, which is not declared", name, stepName), "")
+	}
+	// The results of a StepAction are declared by the StepAction.
+	if step.Ref != nil {
+		return nil
+	}
+	if !slices.ContainsFunc(step.Results, func(r StepResult) bool { return r.Name == resultName }) {
+		return apis.ErrGeneric(fmt.Sprintf("task result %q references result %q of step %q, which the step does not declare", name, resultName, stepName), "")
+	}
+	return nil
+}
+
+// writesTaskResult returns true if the script, command, args or env of the step reference the
+// path of the Task result, either through $(results.<name>.path) or literally.
+func writesTaskResult(step Step, name string) bool {
+	fields := append([]string{step.Script}, step.Command...)
+	fields = append(fields, step.Args...)
+	for _, env := range step.Env {
+		fields = append(fields, env.Value)
+	}
+	for _, field := range fields {
+		if vs, _, _ := substitution.ExtractVariablesFromString(field, "results"); slices.Contains(vs, name) {
+			return true
+		}
+		if strings.Contains(field, filepath.Join(pipeline.DefaultResultPath, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateContinueStepResultsConsumed returns a warning if a step with onError "continue" declares results
+// which are neither consumed by a later step nor surfaced as a Task result, as this is likely dead configuration.
+func (ts *TaskSpec) validateContinueStepResultsConsumed(steps []Step) (errs *apis.FieldError) {
+	// consumed holds "<stepName>.<resultName>" for each step result consumed by a step running after its producer.
+	consumed := sets.NewString()
+	for idx, step := range steps {
+		expressions := step.GetVarSubstitutionExpressions()
+		for _, we := range step.When {
+			whenExpressions, _ := we.GetVarSubstitutionExpressions()
+			expressions = append(expressions, whenExpressions...)
+		}
+		for _, expression := range expressions {
+			pr, err := resultref.ParseStepExpression(expression)
+			if err != nil {
+				continue
+			}
+			if _, producerIdx, found := ts.StepByName(pr.ResourceName); found && producerIdx < idx {
+				consumed.Insert(pr.ResourceName + "." + pr.ResultName)
+			}
+		}
+	}
+	// Task results are consumed after all the steps have run.
+	for _, r := range ts.Results {
+		for _, v := range taskResultValues(r) {
+			if stepName, resultName, err := ExtractStepResultName(v); err == nil {
+				consumed.Insert(stepName + "." + resultName)
+			}
+		}
+	}
+
+	for idx, step := range steps {
+		if step.OnError != Continue {
+			continue
+		}
+		for resultIdx, r := range step.Results {
+			if !consumed.Has(step.Name + "." + r.Name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q of step with onError %q is not consumed by any later step or Task result", r.Name, Continue), "").At(apis.WarningLevel).ViaFieldIndex("results", resultIdx).ViaFieldIndex("steps", idx))
+			}
+		}
+	}
+	return errs
+}
+
+// taskResultValues returns the non-empty string, array and object values of the Task result.
+func taskResultValues(r TaskResult) []string {
+	if r.Value == nil {
+		return nil
+	}
+	var values []string
+	if r.Value.StringVal != "" {
+		values = append(values, r.Value.StringVal)
+	}
+	values = append(values, r.Value.ArrayVal...)
+	for _, k := range sets.StringKeySet(r.Value.ObjectVal).List() {
+		values = append(values, r.Value.ObjectVal[k])
+	}
+	return values
+}
+
+// validateObjectUsage validates the usage of individual attributes of an object param and the usage of the entire object.
+// Individual keys can only be referenced on object params, so key references to other params are rejected.
 func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec) (errs *apis.FieldError) {
 	objectParameterNames := sets.NewString()
 	for _, p := range params {
+		if p.Type != ParamTypeObject {
+			// only object params have keys, e.g. param.stringParam["key1"] is invalid;
+			// params without a type have not been defaulted yet and are skipped
+			if p.Type != "" {
+				errs = errs.Also(validateVariables(ctx, steps, "params\\."+p.Name, sets.NewString()))
+			}
+			continue
+		}
+
 		// collect all names of object type params
 		objectParameterNames.Insert(p.Name)
 
@@ -378,23 +1600,23 @@ func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec)
 		errs = errs.Also(validateVariables(ctx, steps, "params\\."+p.Name, objectKeys))
 	}
 
-	return errs.Also(validateObjectUsageAsWhole(steps, "params", objectParameterNames))
+	return errs.Also(validateObjectUsageAsWhole(ctx, steps, "params", objectParameterNames))
 }
 
 // validateObjectUsageAsWhole returns an error if the Steps contain references to the entire input object params in fields where these references are prohibited
-func validateObjectUsageAsWhole(steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
+func validateObjectUsageAsWhole(ctx context.Context, steps []Step, prefix string, vars sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
-		errs = errs.Also(validateStepObjectUsageAsWhole(step, prefix, vars)).ViaFieldIndex("steps", idx)
+		errs = errs.Also(validateStepObjectUsageAsWhole(ctx, step, prefix, vars).ViaFieldIndex("steps", idx))
 	}
 	return errs
 }
 
 // validateStepObjectUsageAsWhole returns an error if the Step contains references to the entire input object params in fields where these references are prohibited
-func validateStepObjectUsageAsWhole(step Step, prefix string, vars sets.String) *apis.FieldError {
+func validateStepObjectUsageAsWhole(ctx context.Context, step Step, prefix string, vars sets.String) *apis.FieldError {
 	errs := substitution.ValidateNoReferencesToEntireProhibitedVariables(step.Name, prefix, vars).ViaField("name")
 	errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(step.Image, prefix, vars).ViaField("image"))
 	errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(step.WorkingDir, prefix, vars).ViaField("workingDir"))
-	errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(step.Script, prefix, vars).ViaField("script"))
+	errs = errs.Also(validateScriptObjectUsageAsWhole(ctx, step.Script, prefix, vars).ViaField("script"))
 	for i, cmd := range step.Command {
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(cmd, prefix, vars).ViaFieldIndex("command", i))
 	}
@@ -402,20 +1624,49 @@ func validateStepObjectUsageAsWhole(step Step, prefix string, vars sets.String)
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(arg, prefix, vars).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+		// Whole object references are prohibited anywhere in an env value, whether standalone or embedded in a larger string.
+		if err := substitution.ValidateNoReferencesToEntireProhibitedVariables(env.Value, prefix, vars); err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env value %q cannot reference an entire object param, reference an individual key with $(%s.<name>.<key>) instead", env.Value, prefix), "").ViaFieldKey("env", env.Name))
+		}
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.MountPath, prefix, vars).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(v.SubPath, prefix, vars).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(we.Input, prefix, vars).ViaField("input").ViaFieldIndex("when", i))
+		for j, val := range we.Values {
+			errs = errs.Also(substitution.ValidateNoReferencesToEntireProhibitedVariables(val, prefix, vars).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
+// validateScriptObjectUsageAsWhole returns an error if the script references entire input object params.
+// With alpha features enabled, a whole object may be expanded into a script as JSON using the [*] notation.
+func validateScriptObjectUsageAsWhole(ctx context.Context, script, prefix string, vars sets.String) *apis.FieldError {
+	err := substitution.ValidateNoReferencesToEntireProhibitedVariables(script, prefix, vars)
+	if err == nil {
+		return nil
+	}
+	starErr := substitution.ValidateEntireVariablesUseStarNotation(script, prefix, vars)
+	if starErr == nil {
+		if config.ValidateEnabledAPIFields(ctx, "whole object param expansion in script", config.AlphaAPIFields) == nil {
+			return nil
+		}
+		return err
+	}
+	if cfg := config.FromContextOrDefaults(ctx); cfg.FeatureFlags != nil && cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		return starErr
+	}
+	return err
+}
+
 // validateArrayUsage returns an error if the Steps contain references to the input array params in fields where these references are prohibited
 func validateArrayUsage(steps []Step, prefix string, arrayParamNames sets.String) (errs *apis.FieldError) {
 	for idx, step := range steps {
-		errs = errs.Also(validateStepArrayUsage(step, prefix, arrayParamNames)).ViaFieldIndex("steps", idx)
+		errs = errs.Also(validateStepArrayUsage(step, prefix, arrayParamNames).ViaFieldIndex("steps", idx))
 	}
 	return errs
 }
@@ -433,13 +1684,53 @@ func validateStepArrayUsage(step Step, prefix string, arrayParamNames sets.Strin
 		errs = errs.Also(substitution.ValidateVariableReferenceIsIsolated(arg, prefix, arrayParamNames).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(env.Value, prefix, arrayParamNames).ViaFieldKey("env", env.Name))
+		// An env value holds a single string, so only indexed references to array params are allowed.
+		if err := substitution.ValidateNoReferencesToProhibitedVariables(env.Value, prefix, arrayParamNames); err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env value %q cannot reference an entire array param, reference an individual element with $(%s.<name>[i]) instead", env.Value, prefix), "").ViaFieldKey("env", env.Name))
+		}
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.Name, prefix, arrayParamNames).ViaField("name").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.MountPath, prefix, arrayParamNames).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.SubPath, prefix, arrayParamNames).ViaField("subPath").ViaFieldIndex("volumeMount", i))
 	}
+	for i, we := range step.When {
+		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(we.Input, prefix, arrayParamNames).ViaField("input").ViaFieldIndex("when", i))
+		// A whole array may be expanded into values, as long as it is the only content of the value.
+		for j, val := range we.Values {
+			errs = errs.Also(substitution.ValidateVariableReferenceIsIsolated(val, prefix, arrayParamNames).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
+	return errs.Also(validateStepArrayIndexes(step, prefix, arrayParamNames))
+}
+
+// validateStepArrayIndexes returns an error if the Step references elements of the input array params
+// with indexes that are not non-negative integers, e.g. $(params.foo[-1]) or $(params.foo[abc]).
+func validateStepArrayIndexes(step Step, prefix string, arrayParamNames sets.String) *apis.FieldError {
+	errs := substitution.ValidateArrayIndexes(step.Name, prefix, arrayParamNames).ViaField("name")
+	errs = errs.Also(substitution.ValidateArrayIndexes(step.Image, prefix, arrayParamNames).ViaField("image"))
+	errs = errs.Also(substitution.ValidateArrayIndexes(step.WorkingDir, prefix, arrayParamNames).ViaField("workingDir"))
+	errs = errs.Also(substitution.ValidateArrayIndexes(step.Script, prefix, arrayParamNames).ViaField("script"))
+	for i, cmd := range step.Command {
+		errs = errs.Also(substitution.ValidateArrayIndexes(cmd, prefix, arrayParamNames).ViaFieldIndex("command", i))
+	}
+	for i, arg := range step.Args {
+		errs = errs.Also(substitution.ValidateArrayIndexes(arg, prefix, arrayParamNames).ViaFieldIndex("args", i))
+	}
+	for _, env := range step.Env {
+		errs = errs.Also(substitution.ValidateArrayIndexes(env.Value, prefix, arrayParamNames).ViaFieldKey("env", env.Name))
+	}
+	for i, v := range step.VolumeMounts {
+		errs = errs.Also(substitution.ValidateArrayIndexes(v.Name, prefix, arrayParamNames).ViaField("name").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateArrayIndexes(v.MountPath, prefix, arrayParamNames).ViaField("mountPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(substitution.ValidateArrayIndexes(v.SubPath, prefix, arrayParamNames).ViaField("subPath").ViaFieldIndex("volumeMount", i))
+	}
+	for i, we := range step.When {
+		errs = errs.Also(substitution.ValidateArrayIndexes(we.Input, prefix, arrayParamNames).ViaField("input").ViaFieldIndex("when", i))
+		for j, val := range we.Values {
+			errs = errs.Also(substitution.ValidateArrayIndexes(val, prefix, arrayParamNames).ViaFieldIndex("values", j).ViaFieldIndex("when", i))
+		}
+	}
 	return errs
 }
 
@@ -451,7 +1742,120 @@ func validateVariables(ctx context.Context, steps []Step, prefix string, vars se
 	return errs
 }
 
-// ValidateNameFormat validates that the name format of all param types follows the rules
+// validateSidecarProbeVariables returns an error if the readiness or liveness probes of the Sidecars
+// reference params or workspaces that are not declared by the Task.
+func validateSidecarProbeVariables(sidecars []Sidecar, params ParamSpecs, workspaces []WorkspaceDeclaration) (errs *apis.FieldError) {
+	paramNames := params.NameSet()
+	workspaceNames := sets.NewString()
+	for _, w := range workspaces {
+		workspaceNames.Insert(w.Name)
+	}
+	for idx, sc := range sidecars {
+		for _, v := range []struct {
+			prefix string
+			names  sets.String
+		}{{"params", paramNames}, {"workspaces", workspaceNames}} {
+			errs = errs.Also(validateProbeVariables(sc.ReadinessProbe, v.prefix, v.names).ViaField("readinessProbe").ViaFieldIndex("sidecars", idx))
+			errs = errs.Also(validateProbeVariables(sc.LivenessProbe, v.prefix, v.names).ViaField("livenessProbe").ViaFieldIndex("sidecars", idx))
+		}
+	}
+	return errs
+}
+
+// validateSidecarVariables returns an error if the envFrom sources, the securityContext or the
+// terminationMessagePath of the Sidecars reference params that are not declared by the Task.
+func validateSidecarVariables(sidecars []Sidecar, params ParamSpecs) (errs *apis.FieldError) {
+	paramNames := params.NameSet()
+	for idx, sc := range sidecars {
+		errs = errs.Also(validateEnvFromVariables(sc.EnvFrom, "params", paramNames).ViaFieldIndex("sidecars", idx))
+		errs = errs.Also(validateSecurityContextVariables(sc.SecurityContext, "params", paramNames).ViaFieldIndex("sidecars", idx))
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(sc.TerminationMessagePath, "params", paramNames).ViaField("terminationMessagePath").ViaFieldIndex("sidecars", idx))
+	}
+	return errs
+}
+
+// validateSecurityContextVariables returns an error if the string fields of the SecurityContext, which
+// are the only ones that can hold variable references, contain references to any unknown variables.
+func validateSecurityContextVariables(sc *corev1.SecurityContext, prefix string, vars sets.String) (errs *apis.FieldError) {
+	for _, f := range securityContextStringFields(sc) {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(*f.value, prefix, vars).ViaField(f.path))
+	}
+	return errs.ViaField("securityContext")
+}
+
+// securityContextField is a string field of a SecurityContext and its path within the SecurityContext.
+type securityContextField struct {
+	path  string
+	value *string
+}
+
+// securityContextStringFields returns the string fields of the SecurityContext which are set.
+func securityContextStringFields(sc *corev1.SecurityContext) []securityContextField {
+	if sc == nil {
+		return nil
+	}
+	var fields []securityContextField
+	if o := sc.SELinuxOptions; o != nil {
+		fields = append(fields,
+			securityContextField{"seLinuxOptions.user", &o.User},
+			securityContextField{"seLinuxOptions.role", &o.Role},
+			securityContextField{"seLinuxOptions.type", &o.Type},
+			securityContextField{"seLinuxOptions.level", &o.Level})
+	}
+	if o := sc.WindowsOptions; o != nil {
+		if o.GMSACredentialSpecName != nil {
+			fields = append(fields, securityContextField{"windowsOptions.gmsaCredentialSpecName", o.GMSACredentialSpecName})
+		}
+		if o.GMSACredentialSpec != nil {
+			fields = append(fields, securityContextField{"windowsOptions.gmsaCredentialSpec", o.GMSACredentialSpec})
+		}
+		if o.RunAsUserName != nil {
+			fields = append(fields, securityContextField{"windowsOptions.runAsUserName", o.RunAsUserName})
+		}
+	}
+	if p := sc.SeccompProfile; p != nil && p.LocalhostProfile != nil {
+		fields = append(fields, securityContextField{"seccompProfile.localhostProfile", p.LocalhostProfile})
+	}
+	if p := sc.AppArmorProfile; p != nil && p.LocalhostProfile != nil {
+		fields = append(fields, securityContextField{"appArmorProfile.localhostProfile", p.LocalhostProfile})
+	}
+	return fields
+}
+
+// validateEnvFromVariables returns an error if the prefix or the ConfigMap or Secret name of the
+// envFrom sources contain references to any unknown variables
+func validateEnvFromVariables(envFrom []corev1.EnvFromSource, prefix string, vars sets.String) (errs *apis.FieldError) {
+	for i, e := range envFrom {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(e.Prefix, prefix, vars).ViaField("prefix").ViaFieldIndex("envFrom", i))
+		if e.ConfigMapRef != nil {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(e.ConfigMapRef.Name, prefix, vars).ViaField("name").ViaField("configMapRef").ViaFieldIndex("envFrom", i))
+		}
+		if e.SecretRef != nil {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(e.SecretRef.Name, prefix, vars).ViaField("name").ViaField("secretRef").ViaFieldIndex("envFrom", i))
+		}
+	}
+	return errs
+}
+
+// validateProbeVariables returns an error if the exec command or HTTP path of the Probe contains references to any unknown variables
+func validateProbeVariables(probe *corev1.Probe, prefix string, vars sets.String) (errs *apis.FieldError) {
+	if probe == nil {
+		return nil
+	}
+	if probe.Exec != nil {
+		for i, cmd := range probe.Exec.Command {
+			errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(cmd, prefix, vars).ViaFieldIndex("command", i).ViaField("exec"))
+		}
+	}
+	if probe.HTTPGet != nil {
+		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(probe.HTTPGet.Path, prefix, vars).ViaField("path").ViaField("httpGet"))
+	}
+	return errs
+}
+
+// ValidateNameFormat validates that the name format of all param types follows the rules.
+// Besides a summary of the invalid names on "params", each invalid name is reported on its own path,
+// i.e. "params.<name>.name" for param names and "params.<name>.properties.<key>" for object keys.
 func ValidateNameFormat(stringAndArrayParams sets.String, objectParams []ParamSpec) (errs *apis.FieldError) {
 	// checking string or array name format
 	// ----
@@ -461,6 +1865,7 @@ func ValidateNameFormat(stringAndArrayParams sets.String, objectParams []ParamSp
 	for _, name := range stringAndArrayParams.List() {
 		if !stringAndArrayVariableNameFormatRegex.MatchString(name) {
 			invalidStringAndArrayNames = append(invalidStringAndArrayNames, name)
+			errs = errs.Also(apis.ErrInvalidValue(name, paramKeyPath(name, "name")))
 		}
 	}
 
@@ -479,12 +1884,14 @@ func ValidateNameFormat(stringAndArrayParams sets.String, objectParams []ParamSp
 		// check object param name
 		if !objectVariableNameFormatRegex.MatchString(obj.Name) {
 			invalidObjectNames[obj.Name] = []string{}
+			errs = errs.Also(apis.ErrInvalidValue(obj.Name, paramKeyPath(obj.Name, "name")))
 		}
 
 		// check key names
 		for k := range obj.Properties {
 			if !objectVariableNameFormatRegex.MatchString(k) {
 				invalidObjectNames[obj.Name] = append(invalidObjectNames[obj.Name], k)
+				errs = errs.Also(apis.ErrInvalidValue(k, paramKeyPath(obj.Name, fmt.Sprintf("properties[%s]", k))))
 			}
 		}
 	}
@@ -500,30 +1907,85 @@ func ValidateNameFormat(stringAndArrayParams sets.String, objectParams []ParamSp
 	return errs
 }
 
+// validateParamNamePattern returns an error, when the "param-name-pattern" policy is set, for each param
+// whose name does not fully match the pattern. The pattern is applied on top of ValidateNameFormat.
+func validateParamNamePattern(ctx context.Context, params ParamSpecs) (errs *apis.FieldError) {
+	vp := config.ValidationPolicyFromContextOrDefaults(ctx)
+	re, err := vp.GetParamNamePattern()
+	if re == nil || err != nil {
+		return nil
+	}
+	for _, p := range params {
+		if !re.MatchString(p.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param name %q does not match the pattern %q required by the %q validation policy", p.Name, vp.ParamNamePattern, "param-name-pattern"), paramKeyPath(p.Name, "name")))
+		}
+	}
+	return errs
+}
+
+// paramKeyPath returns the path of a field of the param with the given name, e.g. params[foo].name.
+// The path is built directly rather than with ViaFieldKey, which splits names containing dots.
+func paramKeyPath(name, field string) string {
+	return fmt.Sprintf("params[%s].%s", name, field)
+}
+
 // validateStepVariables returns an error if the Step contains references to any unknown variables
 func validateStepVariables(ctx context.Context, step Step, prefix string, vars sets.String) *apis.FieldError {
-	errs := substitution.ValidateNoReferencesToUnknownVariables(step.Name, prefix, vars).ViaField("name")
-	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(step.Image, prefix, vars).ViaField("image"))
-	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(step.WorkingDir, prefix, vars).ViaField("workingDir"))
-	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(step.Script, prefix, vars).ViaField("script"))
+	unknown := substitution.ValidateNoReferencesToUnknownVariables
+	if deduplicatesErrors(ctx) {
+		// The variable is named in the message, so that the errors for the same variable are collapsed.
+		unknown = substitution.ValidateNoReferencesToUnknownVariablesWithDetail
+	}
+	errs := unknown(step.Name, prefix, vars).ViaField("name")
+	errs = errs.Also(unknown(step.Image, prefix, vars).ViaField("image"))
+	errs = errs.Also(unknown(step.WorkingDir, prefix, vars).ViaField("workingDir"))
+	errs = errs.Also(unknown(step.Script, prefix, vars).ViaField("script"))
 	for i, cmd := range step.Command {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(cmd, prefix, vars).ViaFieldIndex("command", i))
+		errs = errs.Also(unknown(cmd, prefix, vars).ViaFieldIndex("command", i))
 	}
 	for i, arg := range step.Args {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(arg, prefix, vars).ViaFieldIndex("args", i))
+		errs = errs.Also(unknown(arg, prefix, vars).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
+		errs = errs.Also(unknown(env.Value, prefix, vars).ViaFieldKey("env", env.Name))
 	}
+	errs = errs.Also(validateEnvFromVariables(step.EnvFrom, prefix, vars))
+	errs = errs.Also(validateSecurityContextVariables(step.SecurityContext, prefix, vars))
 	for i, v := range step.VolumeMounts {
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMount", i))
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.MountPath, prefix, vars).ViaField("MountPath").ViaFieldIndex("volumeMount", i))
-		errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(v.SubPath, prefix, vars).ViaField("SubPath").ViaFieldIndex("volumeMount", i))
+		errs = errs.Also(unknown(v.Name, prefix, vars).ViaField("name").ViaFieldIndex("volumeMounts", i))
+		errs = errs.Also(unknown(v.MountPath, prefix, vars).ViaField("mountPath").ViaFieldIndex("volumeMounts", i))
+		errs = errs.Also(unknown(v.SubPath, prefix, vars).ViaField("subPath").ViaFieldIndex("volumeMounts", i))
 	}
-	errs = errs.Also(substitution.ValidateNoReferencesToUnknownVariables(string(step.OnError), prefix, vars).ViaField("onError"))
+	errs = errs.Also(unknown(string(step.OnError), prefix, vars).ViaField("onError"))
 	return errs
 }
 
+// stepVariableValues returns the values of the Step fields checked by validateStepVariables.
+func stepVariableValues(step Step) []string {
+	values := []string{step.Name, step.Image, step.WorkingDir, step.Script}
+	values = append(values, step.Command...)
+	values = append(values, step.Args...)
+	for _, env := range step.Env {
+		values = append(values, env.Value)
+	}
+	for _, e := range step.EnvFrom {
+		values = append(values, e.Prefix)
+		if e.ConfigMapRef != nil {
+			values = append(values, e.ConfigMapRef.Name)
+		}
+		if e.SecretRef != nil {
+			values = append(values, e.SecretRef.Name)
+		}
+	}
+	for _, v := range step.VolumeMounts {
+		values = append(values, v.Name, v.MountPath, v.SubPath)
+	}
+	for _, f := range securityContextStringFields(step.SecurityContext) {
+		values = append(values, *f.value)
+	}
+	return append(values, string(step.OnError))
+}
+
 // GetIndexingReferencesToArrayParams returns all strings referencing indices of TaskRun array parameters
 // from parameters, workspaces, and when expressions defined in the Task.
 // For example, if a Task has a parameter with a value "$(params.array-param-name[1])",
@@ -17,6 +17,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -26,7 +27,9 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"knative.dev/pkg/apis"
@@ -56,6 +59,97 @@ func TestTaskValidate(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "valid sidecar probe variables",
+		t: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "health-path",
+					Type: v1.ParamTypeString,
+				}},
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name: "shared",
+				}},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					Workspaces: []v1.WorkspaceUsage{{
+						Name:      "shared",
+						MountPath: "/workspace/$(params.health-path)",
+					}},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "server",
+					Image: "my-image",
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							HTTPGet: &corev1.HTTPGetAction{Path: "$(params.health-path)"},
+						},
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{Command: []string{"cat", "$(workspaces.shared.path)/ready"}},
+						},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "valid envFrom variables",
+		t: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "env",
+					Type: v1.ParamTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					EnvFrom: []corev1.EnvFromSource{{
+						Prefix: "$(params.env)_",
+						ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "config-$(params.env)"},
+						},
+					}},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "server",
+					Image: "my-image",
+					EnvFrom: []corev1.EnvFromSource{{
+						SecretRef: &corev1.SecretEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "secret-$(params.env)"},
+						},
+					}},
+				}},
+			},
+		},
+	}, {
+		name: "valid securityContext variables",
+		t: &v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "task"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "user",
+					Type: v1.ParamTypeString,
+				}},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					SecurityContext: &corev1.SecurityContext{
+						WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: pointer.String("$(params.user)")},
+					},
+				}},
+				Sidecars: []v1.Sidecar{{
+					Name:  "server",
+					Image: "my-image",
+					SecurityContext: &corev1.SecurityContext{
+						SELinuxOptions: &corev1.SELinuxOptions{User: "$(params.user)"},
+					},
+				}},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,6 +165,284 @@ func TestTaskValidate(t *testing.T) {
 	}
 }
 
+func TestTaskValidateAndDefault(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name: "foo",
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.foo)"},
+			}},
+		},
+	}
+	original := task.DeepCopy()
+
+	defaulted, err := task.ValidateAndDefault(t.Context())
+	if err != nil {
+		t.Errorf("Task.ValidateAndDefault() returned error for valid Task: %v", err)
+	}
+	if defaulted.Spec.Params[0].Type != v1.ParamTypeString {
+		t.Errorf("Task.ValidateAndDefault() param type = %q, want %q", defaulted.Spec.Params[0].Type, v1.ParamTypeString)
+	}
+	if d := cmp.Diff(original, task); d != "" {
+		t.Errorf("Task.ValidateAndDefault() modified the receiver %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskValidateAndDefault_Error(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name:    "foo",
+				Default: v1.NewStructuredValues("bar", "baz"),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "$(params.foo)",
+			}},
+		},
+	}
+	original := task.DeepCopy()
+
+	defaulted, err := task.ValidateAndDefault(t.Context())
+	expectedError := &apis.FieldError{
+		Message: `variable type invalid in "$(params.foo)"`,
+		Paths:   []string{"spec.steps[0].image"},
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error()); d != "" {
+		t.Errorf("Task.ValidateAndDefault() errors diff %s", diff.PrintWantGot(d))
+	}
+	if defaulted.Spec.Params[0].Type != v1.ParamTypeArray {
+		t.Errorf("Task.ValidateAndDefault() param type = %q, want %q", defaulted.Spec.Params[0].Type, v1.ParamTypeArray)
+	}
+	if d := cmp.Diff(original, task); d != "" {
+		t.Errorf("Task.ValidateAndDefault() modified the receiver %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskValidate_StepTemplateParamUsage(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "gitrepo",
+		Type: v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{
+			"url": {Type: v1.ParamTypeString},
+		},
+	}, {
+		Name: "flags",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "workdir",
+		Type: v1.ParamTypeString,
+	}}
+	tests := []struct {
+		name          string
+		stepTemplate  *v1.StepTemplate
+		expectedError *apis.FieldError
+	}{{
+		name: "params used only in stepTemplate",
+		stepTemplate: &v1.StepTemplate{
+			WorkingDir: "$(params.workdir)",
+			Args:       []string{"$(params.flags[*])", "$(params.gitrepo.url)"},
+		},
+	}, {
+		name: "whole object reference",
+		stepTemplate: &v1.StepTemplate{
+			Args: []string{"--repo=$(params.gitrepo)"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "--repo=$(params.gitrepo)"`,
+			Paths:   []string{"spec.stepTemplate.args[0]"},
+		},
+	}, {
+		name: "array reference in a string field",
+		stepTemplate: &v1.StepTemplate{
+			WorkingDir: "$(params.flags)",
+		},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.flags)"`,
+			Paths:   []string{"spec.stepTemplate.workingDir"},
+		},
+	}, {
+		name: "undeclared param and object key",
+		stepTemplate: &v1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "REVISION",
+				Value: "$(params.revision)",
+			}, {
+				Name:  "BRANCH",
+				Value: "$(params.gitrepo.branch)",
+			}},
+		},
+		expectedError: apis.ErrGeneric(`non-existent variable in "$(params.gitrepo.branch)"`, "spec.stepTemplate.env[BRANCH]").
+			Also(apis.ErrGeneric(`non-existent variable in "$(params.revision)"`, "spec.stepTemplate.env[REVISION]")),
+	}, {
+		name: "undeclared param in a volumeMount",
+		stepTemplate: &v1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "cache",
+				MountPath: "/cache/$(params.cachedir)",
+			}},
+		},
+		expectedError: apis.ErrGeneric(`non-existent variable in "/cache/$(params.cachedir)"`, "spec.stepTemplate.volumeMounts[0].mountPath"),
+	}, {
+		name: "undeclared param in the securityContext",
+		stepTemplate: &v1.StepTemplate{
+			SecurityContext: &corev1.SecurityContext{
+				SELinuxOptions: &corev1.SELinuxOptions{Level: "$(params.level)"},
+			},
+		},
+		expectedError: apis.ErrGeneric(`non-existent variable in "$(params.level)"`, "spec.stepTemplate.securityContext.seLinuxOptions.level"),
+	}, {
+		name: "declared params in a volumeMount and the securityContext",
+		stepTemplate: &v1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "workdir",
+				MountPath: "$(params.workdir)",
+				SubPath:   "$(params.gitrepo.url)",
+			}},
+			SecurityContext: &corev1.SecurityContext{
+				SELinuxOptions: &corev1.SELinuxOptions{User: "$(params.workdir)"},
+			},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1.TaskSpec{
+					Params:       params,
+					StepTemplate: tt.stepTemplate,
+					Steps: []v1.Step{{
+						Name:  "mystep",
+						Image: "myimage",
+					}},
+				},
+			}
+			err := task.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidate_DisplayNameVariables(t *testing.T) {
+	tests := []struct {
+		name          string
+		displayName   string
+		expectedError *apis.FieldError
+	}{{
+		name:        "valid param and context references",
+		displayName: "Build $(params.image) in $(context.taskRun.namespace) for $(context.task.name)",
+	}, {
+		name:        "unknown param reference",
+		displayName: "Build $(params.missing)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "Build $(params.missing)"`,
+			Paths:   []string{"spec.displayName"},
+		},
+	}, {
+		name:        "unknown context reference",
+		displayName: "Build $(context.taskRun.missing)",
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "Build $(context.taskRun.missing)"`,
+			Paths:   []string{"spec.displayName"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1.TaskSpec{
+					DisplayName: tt.displayName,
+					Params: []v1.ParamSpec{{
+						Name: "image",
+						Type: v1.ParamTypeString,
+					}},
+					Steps: validSteps,
+				},
+			}
+			err := task.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidate_UnpinnedPlatformImages(t *testing.T) {
+	const digest = "@sha256:7a5d5ab8ba5e8d6d4d5d2e3b5c7a4d9b5e0c9d6b1f3a1a6c2e6f3c9d8b7a6e5f"
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		flag        bool
+		wantWarning string
+	}{{
+		name:        "platform annotation with unpinned images",
+		annotations: map[string]string{v1.TargetPlatformAnnotation: "linux/arm64"},
+		flag:        true,
+		wantWarning: `image "my-image:latest" is not pinned by digest and may not resolve to an image for the target platform "linux/arm64": spec.steps[0].image
+image "sidecar-image" is not pinned by digest and may not resolve to an image for the target platform "linux/arm64": spec.sidecars[0].image`,
+	}, {
+		name: "no platform annotation",
+		flag: true,
+	}, {
+		name:        "flag disabled",
+		annotations: map[string]string{v1.TargetPlatformAnnotation: "linux/arm64"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task", Annotations: tt.annotations},
+				Spec: v1.TaskSpec{
+					Params: []v1.ParamSpec{{
+						Name: "image",
+						Type: v1.ParamTypeString,
+					}},
+					Steps: []v1.Step{{
+						Name:  "unpinned",
+						Image: "my-image:latest",
+					}, {
+						Name:  "pinned",
+						Image: "my-image" + digest,
+					}, {
+						Name:  "templated",
+						Image: "$(params.image)",
+					}},
+					Sidecars: []v1.Sidecar{{
+						Name:  "sidecar",
+						Image: "sidecar-image",
+					}},
+				},
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					WarnUnpinnedPlatformImages: tt.flag,
+				},
+			})
+			err := task.Validate(ctx)
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("Task.Validate() returned error: %v", err)
+			}
+			if tt.wantWarning == "" {
+				if w := err.Filter(apis.WarningLevel); w != nil {
+					t.Errorf("Task.Validate() returned unexpected warning: %v", w)
+				}
+				return
+			}
+			if d := cmp.Diff(tt.wantWarning, err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("Task.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidatePropagatedParamsAndWorkspaces(t *testing.T) {
 	type fields struct {
 		Params       []v1.ParamSpec
@@ -143,16 +515,29 @@ func TestTaskSpecValidatePropagatedParamsAndWorkspaces(t *testing.T) {
 	}
 }
 
+// withValidationPolicy returns ctx with the validation policy, if not nil, in addition to its feature flags.
+func withValidationPolicy(ctx context.Context, policy *config.ValidationPolicy) context.Context {
+	if policy == nil {
+		return ctx
+	}
+	return config.ToContext(ctx, &config.Config{
+		FeatureFlags:     config.FromContextOrDefaults(ctx).FeatureFlags,
+		ValidationPolicy: policy,
+	})
+}
+
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
 		Params       []v1.ParamSpec
 		Steps        []v1.Step
+		Sidecars     []v1.Sidecar
 		StepTemplate *v1.StepTemplate
 		Workspaces   []v1.WorkspaceDeclaration
 		Results      []v1.TaskResult
 	}
 	tests := []struct {
 		name   string
+		policy *config.ValidationPolicy
 		fields fields
 	}{{
 		name: "valid params type implied",
@@ -164,6 +549,20 @@ func TestTaskSpecValidate(t *testing.T) {
 			}},
 			Steps: validSteps,
 		},
+	}, {
+		name: "escaped reference to an undeclared param",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "real",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: []v1.Step{{
+				Name:   "mystep",
+				Image:  "myimage",
+				Script: "echo $$(params.notvar) $(params.real)",
+				Args:   []string{"$$(notvar)"},
+			}},
+		},
 	}, {
 		name: "valid params type explicit",
 		fields: fields{
@@ -270,7 +669,7 @@ func TestTaskSpecValidate(t *testing.T) {
 			Steps: []v1.Step{{
 				Name:       "mystep",
 				Image:      "myimage",
-				Command:    []string{"$(params.foo-is-baz)"},
+				Command:    []string{"$(params.foo-is-baz[*])"},
 				Args:       []string{"$(params.baz[*])", "middle string", "$(params.foo-is-baz[*])"},
 				WorkingDir: "/foo/bar/src/",
 			}},
@@ -368,7 +767,7 @@ func TestTaskSpecValidate(t *testing.T) {
 			Workspaces: []v1.WorkspaceDeclaration{{
 				Name:        "foo-workspace",
 				Description: "my great workspace",
-				MountPath:   "some/path",
+				MountPath:   "/some/path",
 			}},
 		},
 	}, {
@@ -376,7 +775,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		fields: fields{
 			Steps: []v1.Step{{
 				Image: "my-image",
-				Args:  []string{"arg"},
+				Args:  []string{"$(results.MY-RESULT.path)"},
 			}},
 			Results: []v1.TaskResult{{
 				Name:        "MY-RESULT",
@@ -388,7 +787,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		fields: fields{
 			Steps: []v1.Step{{
 				Image: "my-image",
-				Args:  []string{"arg"},
+				Args:  []string{"$(results.MY-RESULT.path)"},
 			}},
 			Results: []v1.TaskResult{{
 				Name:        "MY-RESULT",
@@ -401,7 +800,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		fields: fields{
 			Steps: []v1.Step{{
 				Image: "my-image",
-				Args:  []string{"arg"},
+				Args:  []string{"$(results.MY-RESULT.path)"},
 			}},
 			Results: []v1.TaskResult{{
 				Name:        "MY-RESULT",
@@ -414,7 +813,7 @@ func TestTaskSpecValidate(t *testing.T) {
 		fields: fields{
 			Steps: []v1.Step{{
 				Image: "my-image",
-				Args:  []string{"arg"},
+				Args:  []string{"$(results.MY-RESULT.path)"},
 			}},
 			Results: []v1.TaskResult{{
 				Name:        "MY-RESULT",
@@ -491,871 +890,753 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.namespace)"`,
 			}},
 		},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Params:       tt.fields.Params,
-				Steps:        tt.fields.Steps,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
-			}
-			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
-			}
-		})
-	}
-}
-
-func TestTaskSpecStepActionReferenceValidate(t *testing.T) {
-	tests := []struct {
-		name  string
-		Steps []v1.Step
-	}{{
-		name: "valid stepaction ref",
-		Steps: []v1.Step{{
-			Name: "mystep",
-			Ref: &v1.Ref{
-				Name: "stepAction",
-			},
-		}},
 	}, {
-		name: "valid use of params with Ref",
-		Steps: []v1.Step{{
-			Ref: &v1.Ref{
-				Name: "stepAction",
-			},
-			Params: v1.Params{{
-				Name: "param",
-			}},
-		}},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps: tt.Steps,
-			}
-			ctx := t.Context()
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
-			}
-		})
-	}
-}
-
-func TestTaskValidateError(t *testing.T) {
-	type fields struct {
-		Params []v1.ParamSpec
-		Steps  []v1.Step
-	}
-	tests := []struct {
-		name          string
-		fields        fields
-		expectedError apis.FieldError
-	}{{
-		name: "inexistent param variable",
+		name: "valid taskRun uid in step script with deterministic workspace mountPath",
 		fields: fields{
 			Steps: []v1.Step{{
-				Name:  "mystep",
-				Image: "myimage",
-				Args:  []string{"--flag=$(params.inexistent)"},
+				Name:   "mystep",
+				Image:  "myimage",
+				Script: "echo $(context.taskRun.uid)",
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "cache",
+				MountPath: "/cache",
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "object used in a string field",
+		name: "valid continue step result consumed by a later step",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
 			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "$(params.gitrepo)",
-				Args:       []string{"echo"},
-				WorkingDir: "/foo/bar/src/",
+				Name:    "producer",
+				Image:   "my-image",
+				Args:    []string{"$(step.results.out.path)"},
+				OnError: v1.Continue,
+				Results: []v1.StepResult{{Name: "out"}},
+			}, {
+				Name:  "consumer",
+				Image: "my-image",
+				Env: []corev1.EnvVar{{
+					Name:  "OUT",
+					Value: "$(steps.producer.results.out)",
+				}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"spec.steps[0].image"},
-		},
 	}, {
-		name: "object star used in a string field",
+		name: "valid continue step result consumed by a task result",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
 			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "$(params.gitrepo[*])",
-				Args:       []string{"echo"},
-				WorkingDir: "/foo/bar/src/",
+				Name:    "producer",
+				Image:   "my-image",
+				Args:    []string{"$(step.results.out.path)"},
+				OnError: v1.Continue,
+				Results: []v1.StepResult{{Name: "out"}},
+			}},
+			Results: []v1.TaskResult{{
+				Name:  "out",
+				Value: v1.NewStructuredValues("$(steps.producer.results.out)"),
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"spec.steps[0].image"},
 		},
 	}, {
-		name: "object used in a field that can accept array type",
+		name: "valid continue step result consumed by an array task result value",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
 			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "myimage",
-				Args:       []string{"$(params.gitrepo)"},
-				WorkingDir: "/foo/bar/src/",
+				Name:    "producer",
+				Image:   "my-image",
+				Args:    []string{"$(step.results.out.path)"},
+				OnError: v1.Continue,
+				Results: []v1.StepResult{{Name: "out"}},
+			}},
+			Results: []v1.TaskResult{{
+				Name:  "out",
+				Type:  v1.ResultsTypeArray,
+				Value: &v1.ParamValue{Type: v1.ParamTypeString, ArrayVal: []string{"$(steps.producer.results.out)"}},
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "object star used in a field that can accept array type",
+		name: "step result written by script",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
 			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "some-git-image",
-				Args:       []string{"$(params.gitrepo[*])"},
-				WorkingDir: "/foo/bar/src/",
+				Name:    "writer",
+				Image:   "my-image",
+				Script:  "date | tee $(step.results.out.path)",
+				Results: []v1.StepResult{{Name: "out"}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
-		},
 	}, {
-		name: "non-existent individual key of an object param is used in task step",
+		name: "step result written through env",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "gitrepo",
-				Type: v1.ParamTypeObject,
-				Properties: map[string]v1.PropertySpec{
-					"url":    {},
-					"commit": {},
-				},
-			}},
 			Steps: []v1.Step{{
-				Name:       "do-the-clone",
-				Image:      "some-git-image",
-				Args:       []string{"$(params.gitrepo.non-exist-key)"},
-				WorkingDir: "/foo/bar/src/",
+				Name:  "writer",
+				Image: "my-image",
+				Env: []corev1.EnvVar{{
+					Name:  "OUT_PATH",
+					Value: "$(results.out.path)",
+				}},
+				Results: []v1.StepResult{{Name: "out"}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
-		},
 	}, {
-		name: "Inexistent param variable in volumeMount with existing",
+		name: "distinct step when expressions",
 		fields: fields{
-			Params: []v1.ParamSpec{
-				{
-					Name:        "foo",
-					Description: "param",
-					Default:     v1.NewStructuredValues("default"),
-				},
-			},
 			Steps: []v1.Step{{
 				Name:  "mystep",
 				Image: "myimage",
-				VolumeMounts: []corev1.VolumeMount{{
-					Name: "$(params.inexistent)-foo",
+				When: v1.StepWhenExpressions{{
+					Input:    "foo",
+					Operator: selection.In,
+					Values:   []string{"foo"},
+				}, {
+					Input:    "foo",
+					Operator: selection.NotIn,
+					Values:   []string{"foo"},
 				}},
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.inexistent)-foo"`,
-			Paths:   []string{"spec.steps[0].volumeMount[0].name"},
-		},
 	}, {
-		name: "Inexistent param variable with existing",
+		name: "literal param defaults",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "foo",
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
+				Name:    "foo",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewStructuredValues("bar"),
+			}, {
+				Name:    "baz",
+				Type:    v1.ParamTypeArray,
+				Default: v1.NewStructuredValues("bar", "baz"),
 			}},
+			Steps: validSteps,
+		},
+	}, {
+		name: "task results written through their path",
+		fields: fields{
 			Steps: []v1.Step{{
-				Name:  "mystep",
+				Name:   "script-writer",
+				Image:  "myimage",
+				Script: "date | tee $(results.from-script.path)",
+			}, {
+				Name:    "command-writer",
+				Image:   "myimage",
+				Command: []string{"sh", "-c", "echo -n ok > /tekton/results/literal"},
+			}, {
+				Name:  "env-writer",
 				Image: "myimage",
-				Args:  []string{"$(params.foo) && $(params.inexistent)"},
+				Env:   []corev1.EnvVar{{Name: "OUT", Value: "$(results.from-env.path)"}},
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
-			Paths:   []string{"spec.steps[0].args[0]"},
+			Results: []v1.TaskResult{{Name: "from-script"}, {Name: "literal"}, {Name: "from-env"}},
 		},
 	}, {
-		name: "invalid step - invalid onError usage - set to a parameter which does not exist in the task",
+		name: "task result produced by a step result",
 		fields: fields{
 			Steps: []v1.Step{{
-				OnError: "$(params.CONTINUE)",
-				Image:   "image",
-				Args:    []string{"arg"},
+				Name:    "producer",
+				Image:   "myimage",
+				Script:  "date | tee $(step.results.out.path)",
+				Results: []v1.StepResult{{Name: "out"}},
+			}},
+			Results: []v1.TaskResult{{
+				Name:  "out",
+				Value: v1.NewStructuredValues("$(steps.producer.results.out)"),
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: "non-existent variable in \"$(params.CONTINUE)\"",
-			Paths:   []string{"spec.steps[0].onError"},
-		},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			task := &v1.Task{
-				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
-				Spec: v1.TaskSpec{
-					Params: tt.fields.Params,
-					Steps:  tt.fields.Steps,
-				},
-			}
-			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-			task.SetDefaults(ctx)
-			err := task.Validate(ctx)
-			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", task)
-			}
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
-			}
-		})
-	}
-}
-
-func TestTaskSpecValidateError(t *testing.T) {
-	type fields struct {
-		Params       []v1.ParamSpec
-		Steps        []v1.Step
-		Volumes      []corev1.Volume
-		StepTemplate *v1.StepTemplate
-		Workspaces   []v1.WorkspaceDeclaration
-		Results      []v1.TaskResult
-	}
-	tests := []struct {
-		name          string
-		fields        fields
-		expectedError apis.FieldError
-	}{{
-		name: "empty spec",
-		expectedError: apis.FieldError{
-			Message: `missing field(s)`,
-			Paths:   []string{"steps"},
+	}, {
+		name: "task result written by a step guarded by when expressions and an unguarded step",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:   "guarded",
+				Image:  "myimage",
+				Script: "date | tee $(results.out.path)",
+				When:   v1.StepWhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+			}, {
+				Name:   "fallback",
+				Image:  "myimage",
+				Script: "test -f $(results.out.path) || date | tee $(results.out.path)",
+			}},
+			Results: []v1.TaskResult{{Name: "out"}},
 		},
 	}, {
-		name: "no step",
+		name:   "unnamed step allowed when the require-step-names policy is off",
+		policy: &config.ValidationPolicy{RequireStepNames: false},
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "validparam",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
+			Steps: []v1.Step{{
+				Name:  "named",
+				Image: "myimage",
+			}, {
+				Image: "myimage",
 			}},
 		},
-		expectedError: apis.FieldError{
-			Message: `missing field(s)`,
-			Paths:   []string{"steps"},
+	}, {
+		name:   "missing step limits allowed when the require-step-resource-limits policy is off",
+		policy: &config.ValidationPolicy{RequireStepResourceLimits: false},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "unlimited",
+				Image: "myimage",
+			}},
 		},
 	}, {
-		name: "step script refers to nonexistent result",
+		name:   "step limits declared through the stepTemplate",
+		policy: &config.ValidationPolicy{RequireStepResourceLimits: true},
 		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				ComputeResources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}},
+			},
 			Steps: []v1.Step{{
-				Name:  "step-name",
-				Image: "my-image",
-				Script: `
-				#!/usr/bin/env bash
-				date | tee $(results.non-exist.path)`,
+				Name:  "limited",
+				Image: "myimage",
 			}},
-			Results: []v1.TaskResult{{Name: "a-result"}},
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
-			Paths:   []string{"steps[0].script"},
+	}, {
+		name:   "workspaces at the max-workspace-count limit",
+		policy: &config.ValidationPolicy{MaxWorkspaceCount: 2},
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{Name: "workspace-0"}, {Name: "workspace-1"}},
 		},
 	}, {
-		name: "invalid param name format",
+		name:   "workspaces not limited when max-workspace-count is 0",
+		policy: &config.ValidationPolicy{MaxWorkspaceCount: 0},
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{Name: "workspace-0"}, {Name: "workspace-1"}, {Name: "workspace-2"}},
+		},
+	}, {
+		name:   "param names not checked when the param-name-pattern policy is not set",
+		policy: &config.ValidationPolicy{ParamNamePattern: ""},
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "_validparam1",
-				Description: "valid param name format",
-			}, {
-				Name:        "valid_param2",
-				Description: "valid param name format",
-			}, {
-				Name:        "",
-				Description: "invalid param name format",
-			}, {
-				Name:        "a^b",
-				Description: "invalid param name format",
+				Name: "git-url",
+				Type: v1.ParamTypeString,
 			}, {
-				Name:        "0ab",
-				Description: "invalid param name format",
-			}, {
-				Name:        "f oo",
-				Description: "invalid param name format",
+				Name: "gitRevision",
+				Type: v1.ParamTypeString,
 			}},
 			Steps: validSteps,
 		},
-		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
-			Paths:   []string{"params"},
-			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
-		},
 	}, {
-		name: "invalid object param format - object param name and key name shouldn't contain dots.",
+		name:   "param names matching the param-name-pattern policy",
+		policy: &config.ValidationPolicy{ParamNamePattern: "[a-z][a-z0-9-]*"},
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "invalid.name1",
-				Description: "object param name contains dots",
-				Properties: map[string]v1.PropertySpec{
-					"invalid.key1": {},
-					"mykey2":       {},
-				},
+				Name: "git-url",
+				Type: v1.ParamTypeString,
 			}},
 			Steps: validSteps,
 		},
-		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
-				"invalid.name1": {"invalid.key1"},
-			}),
-			Paths:   []string{"params"},
-			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
+	}, {
+		name:   "privilege escalation allowed when the deny-privilege-escalation policy is off",
+		policy: &config.ValidationPolicy{DenyPrivilegeEscalation: false},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:            "mystep",
+				Image:           "myimage",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(true)},
+			}},
 		},
 	}, {
-		name: "duplicated param names",
+		name:   "privilege escalation set to false or unset under the deny-privilege-escalation policy",
+		policy: &config.ValidationPolicy{DenyPrivilegeEscalation: true},
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "foo",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("value1"),
+			Steps: []v1.Step{{
+				Name:            "mystep",
+				Image:           "myimage",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(false)},
 			}, {
-				Name:        "foo",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("value2"),
+				Name:  "otherstep",
+				Image: "myimage",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:            "mysidecar",
+				Image:           "myimage",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(false)},
 			}},
-			Steps: validSteps,
 		},
-		expectedError: apis.FieldError{
-			Message: `parameter appears more than once`,
-			Paths:   []string{"params[foo]"},
+	}, {
+		name:   "images from the allowed-image-registries policy",
+		policy: &config.ValidationPolicy{AllowedImageRegistries: "gcr.io,localhost:5000,docker.io"},
+		fields: fields{
+			Params: []v1.ParamSpec{{Name: "registry", Type: v1.ParamTypeString}},
+			Steps: []v1.Step{{
+				Name:  "registry",
+				Image: "gcr.io/project/image:latest",
+			}, {
+				Name:  "registry-with-port",
+				Image: "localhost:5000/image",
+			}, {
+				Name:  "no-registry-pulled-from-docker-io",
+				Image: "library/ubuntu",
+			}, {
+				Name:  "templated-image-skipped",
+				Image: "$(params.registry)/image",
+			}},
 		},
 	}, {
-		name: "invalid param type",
+		name:   "descriptions at the max-description-length limit",
+		policy: &config.ValidationPolicy{MaxDescriptionLength: 10},
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "validparam",
+				Name:        "param",
 				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
-			}, {
-				Name:        "param-with-invalid-type",
-				Type:        "invalidtype",
-				Description: "invalidtypedesc",
-				Default:     v1.NewStructuredValues("default"),
+				Description: strings.Repeat("a", 10),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.result.path)"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "result",
+				Description: strings.Repeat("a", 10),
 			}},
-			Steps: validSteps,
-		},
-		expectedError: apis.FieldError{
-			Message: `invalid value: invalidtype`,
-			Paths:   []string{"params.param-with-invalid-type.type"},
 		},
 	}, {
-		name: "param mismatching default/type 1",
+		name:   "missing descriptions allowed when the require-descriptions policy is off",
+		policy: &config.ValidationPolicy{RequireDescriptions: false},
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeArray,
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
+				Name: "param",
+				Type: v1.ParamTypeString,
 			}},
-			Steps: validSteps,
-		},
-		expectedError: apis.FieldError{
-			Message: `"array" type does not match default value's type: "string"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.result.path)"},
+			}},
+			Results: []v1.TaskResult{{Name: "result"}},
 		},
 	}, {
-		name: "param mismatching default/type 2",
+		name:   "descriptions declared under the require-descriptions policy",
+		policy: &config.ValidationPolicy{RequireDescriptions: true},
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:        "task",
+				Name:        "param",
 				Type:        v1.ParamTypeString,
-				Description: "param",
-				Default:     v1.NewStructuredValues("default", "array"),
+				Description: "some description",
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.result.path)"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "result",
+				Description: "some description",
 			}},
-			Steps: validSteps,
-		},
-		expectedError: apis.FieldError{
-			Message: `"string" type does not match default value's type: "array"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
 		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params:       tt.fields.Params,
+				Steps:        tt.fields.Steps,
+				Sidecars:     tt.fields.Sidecars,
+				StepTemplate: tt.fields.StepTemplate,
+				Workspaces:   tt.fields.Workspaces,
+				Results:      tt.fields.Results,
+			}
+			ctx := withValidationPolicy(cfgtesting.EnableAlphaAPIFields(t.Context()), tt.policy)
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskSpecStepActionReferenceValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		Steps []v1.Step
+	}{{
+		name: "valid stepaction ref",
+		Steps: []v1.Step{{
+			Name: "mystep",
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+		}},
 	}, {
-		name: "param mismatching default/type 3",
+		name: "valid use of params with Ref",
+		Steps: []v1.Step{{
+			Ref: &v1.Ref{
+				Name: "stepAction",
+			},
+			Params: v1.Params{{
+				Name: "param",
+			}},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.Steps,
+			}
+			ctx := t.Context()
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskValidateError(t *testing.T) {
+	type fields struct {
+		Params     []v1.ParamSpec
+		Steps      []v1.Step
+		Sidecars   []v1.Sidecar
+		Workspaces []v1.WorkspaceDeclaration
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		expectedError apis.FieldError
+	}{{
+		name: "inexistent param variable in step workspace mount path",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeArray,
-				Description: "param",
-				Default: v1.NewObject(map[string]string{
-					"key1": "var1",
-					"key2": "var2",
-				}),
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "shared",
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name:      "shared",
+					MountPath: "/workspace/$(params.inexistent)",
+				}},
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `"array" type does not match default value's type: "object"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Message: `non-existent variable in "/workspace/$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].workspaces[0].mountPath"},
 		},
 	}, {
-		name: "param mismatching default/type 4",
+		name: "inexistent param variable in sidecar readiness probe exec command",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeObject,
-				Description: "param",
-				Properties:  map[string]v1.PropertySpec{"key1": {}},
-				Default:     v1.NewStructuredValues("var"),
-			}},
 			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:  "server",
+				Image: "my-image",
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						Exec: &corev1.ExecAction{Command: []string{"check", "$(params.inexistent)"}},
+					},
+				},
+			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `"object" type does not match default value's type: "string"`,
-			Paths:   []string{"params.task.type", "params.task.default.type"},
+			Message: `non-existent variable in "$(params.inexistent)"`,
+			Paths:   []string{"spec.sidecars[0].readinessProbe.exec.command[1]"},
 		},
 	}, {
-		name: "PropertySpec type is set with unsupported type",
+		name: "inexistent param variable in step envFrom configMapRef name",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "task",
-				Type:        v1.ParamTypeObject,
-				Description: "param",
-				Properties: map[string]v1.PropertySpec{
-					"key1": {Type: "number"},
-					"key2": {Type: "string"},
-				},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				EnvFrom: []corev1.EnvFromSource{{
+					ConfigMapRef: &corev1.ConfigMapEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "config-$(params.inexistent)"},
+					},
+				}},
 			}},
-			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
-			Paths:   []string{"params.task.properties"},
+			Message: `non-existent variable in "config-$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].envFrom[0].configMapRef.name"},
 		},
 	}, {
-		name: "invalid step",
+		name: "inexistent param variable in sidecar envFrom secretRef name",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name:        "validparam",
-				Type:        v1.ParamTypeString,
-				Description: "parameter",
-				Default:     v1.NewStructuredValues("default"),
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:  "server",
+				Image: "my-image",
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "$(params.inexistent)"},
+					},
+				}},
 			}},
-			Steps: []v1.Step{},
 		},
 		expectedError: apis.FieldError{
-			Message: "missing field(s)",
-			Paths:   []string{"steps"},
+			Message: `non-existent variable in "$(params.inexistent)"`,
+			Paths:   []string{"spec.sidecars[0].envFrom[0].secretRef.name"},
 		},
 	}, {
-		name: "duplicate step name",
+		name: "step name with reserved prefix",
 		fields: fields{
-			Steps: []v1.Step{
-				{Name: "mystep", Image: "myimage"},
-				{Name: "mystep", Image: "myimage"},
-			},
+			Steps: []v1.Step{{
+				Name:  "build",
+				Image: "myimage",
+			}, {
+				Name:  "unnamed-1",
+				Image: "myimage",
+			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `expected exactly one, got both`,
-			Paths:   []string{"steps[1].name"},
+			Message: `invalid value "unnamed-1"`,
+			Paths:   []string{"spec.steps[1].name"},
+			Details: `Task step name cannot be "unnamed-" followed by a number, which is reserved for the names generated for unnamed steps`,
 		},
 	}, {
-		name: "array used in a string field",
+		name: "inexistent param variable in step securityContext runAsUserName",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
 			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "$(params.baz)",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"$(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
+				Name:  "mystep",
+				Image: "myimage",
+				SecurityContext: &corev1.SecurityContext{
+					WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: pointer.String("$(params.inexistent)")},
+				},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz)"`,
-			Paths:   []string{"steps[0].image"},
+			Message: `non-existent variable in "$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].securityContext.windowsOptions.runAsUserName"},
 		},
 	}, {
-		name: "array star used in a string field",
+		name: "inexistent param variable in sidecar securityContext seLinuxOptions",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "$(params.baz[*])",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"$(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:  "server",
+				Image: "my-image",
+				SecurityContext: &corev1.SecurityContext{
+					SELinuxOptions: &corev1.SELinuxOptions{Level: "$(params.inexistent)"},
+				},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz[*])"`,
-			Paths:   []string{"steps[0].image"},
+			Message: `non-existent variable in "$(params.inexistent)"`,
+			Paths:   []string{"spec.sidecars[0].securityContext.seLinuxOptions.level"},
 		},
 	}, {
-		name: "array star used illegally in script field",
+		name: "inexistent param variable in sidecar terminationMessagePath",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:                   "server",
+				Image:                  "my-image",
+				TerminationMessagePath: "/var/log/$(params.inexistent)",
 			}},
-			Steps: []v1.Step{
-				{
-					Script:     "$(params.baz[*])",
-					Name:       "mystep",
-					Image:      "my-image",
-					WorkingDir: "/foo/bar/src/",
-				},
-			},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz[*])"`,
-			Paths:   []string{"steps[0].script"},
+			Message: `non-existent variable in "/var/log/$(params.inexistent)"`,
+			Paths:   []string{"spec.sidecars[0].terminationMessagePath"},
 		},
 	}, {
-		name: "array param used in step env value field that can accept string type",
+		name: "inexistent workspace variable in sidecar liveness probe http path",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}},
-			Steps: []v1.Step{{
-				Name:  "mystep",
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:  "server",
 				Image: "my-image",
-				Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.baz)"}},
+				LivenessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{Path: "$(workspaces.inexistent.path)"},
+					},
+				},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.baz)"`,
-			Paths:   []string{"steps[0].env[URL]"},
+			Message: `non-existent variable in "$(workspaces.inexistent.path)"`,
+			Paths:   []string{"spec.sidecars[0].livenessProbe.httpGet.path"},
 		},
 	}, {
-		name: "array not properly isolated",
+		name: "inexistent param variable",
 		fields: fields{
-			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
-			}},
 			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
-				WorkingDir: "/foo/bar/src/",
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--flag=$(params.inexistent)"},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "array star not properly isolated",
+		name: "object used in a string field",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name: "baz",
-				Type: v1.ParamTypeArray,
-			}, {
-				Name: "foo-is-baz",
-				Type: v1.ParamTypeArray,
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
 			}},
 			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo)",
+				Args:       []string{"echo"},
 				WorkingDir: "/foo/bar/src/",
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"spec.steps[0].image"},
 		},
 	}, {
-		name: "inferred array not properly isolated",
+		name: "object star used in a string field",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:    "baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}, {
-				Name:    "foo-is-baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
 			}},
 			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				Name:       "do-the-clone",
+				Image:      "$(params.gitrepo[*])",
+				Args:       []string{"echo"},
 				WorkingDir: "/foo/bar/src/",
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"spec.steps[0].image"},
 		},
 	}, {
-		name: "inferred array star not properly isolated",
+		name: "object used in a field that can accept array type",
 		fields: fields{
 			Params: []v1.ParamSpec{{
-				Name:    "baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
-			}, {
-				Name:    "foo-is-baz",
-				Default: v1.NewStructuredValues("implied", "array", "type"),
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
 			}},
 			Steps: []v1.Step{{
-				Name:       "mystep",
-				Image:      "someimage",
-				Command:    []string{"$(params.foo-is-baz)"},
-				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				Name:       "do-the-clone",
+				Image:      "myimage",
+				Args:       []string{"$(params.gitrepo)"},
 				WorkingDir: "/foo/bar/src/",
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "Multiple volumes with same name",
+		name: "object star used in a field that can accept array type",
 		fields: fields{
-			Steps: validSteps,
-			Volumes: []corev1.Volume{{
-				Name: "workspace",
-			}, {
-				Name: "workspace",
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
 			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `multiple volumes with same name "workspace"`,
-			Paths:   []string{"volumes[1].name"},
-		},
-	}, {
-		name: "declared workspaces names are not unique",
-		fields: fields{
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "same-workspace",
-				MountPath: "/foo",
-			}, {
-				Name:      "same-workspace",
-				MountPath: "/bar",
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "some-git-image",
+				Args:       []string{"$(params.gitrepo[*])"},
+				WorkingDir: "/foo/bar/src/",
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: "workspace name \"same-workspace\" must be unique",
-			Paths:   []string{"workspaces[1].name"},
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "declared workspaces clash with each other",
+		name: "non-existent individual key of an object param is used in task step",
 		fields: fields{
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
-			}, {
-				Name:      "another-workspace",
-				MountPath: "/foo",
+			Params: []v1.ParamSpec{{
+				Name: "gitrepo",
+				Type: v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{
+					"url":    {},
+					"commit": {},
+				},
+			}},
+			Steps: []v1.Step{{
+				Name:       "do-the-clone",
+				Image:      "some-git-image",
+				Args:       []string{"$(params.gitrepo.non-exist-key)"},
+				WorkingDir: "/foo/bar/src/",
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[1].mountpath"},
+			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "workspace mount path already in volumeMounts",
+		name: "Inexistent param variable in volumeMount with existing",
 		fields: fields{
+			Params: []v1.ParamSpec{
+				{
+					Name:        "foo",
+					Description: "param",
+					Default:     v1.NewStructuredValues("default"),
+				},
+			},
 			Steps: []v1.Step{{
-				Image:   "myimage",
-				Command: []string{"command"},
+				Name:  "mystep",
+				Image: "myimage",
 				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/foo",
+					Name: "$(params.inexistent)-foo",
 				}},
 			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
-			}},
 		},
 		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
+			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Paths:   []string{"spec.steps[0].volumeMounts[0].name"},
 		},
 	}, {
-		name: "workspace default mount path already in volumeMounts",
+		name: "Inexistent param variable with existing",
 		fields: fields{
-			Steps: []v1.Step{{
-				Image:   "myimage",
-				Command: []string{"command"},
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/workspace/some-workspace/",
-				}},
+			Params: []v1.ParamSpec{{
+				Name:        "foo",
+				Description: "param",
+				Default:     v1.NewStructuredValues("default"),
 			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name: "some-workspace",
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.foo) && $(params.inexistent)"},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
+			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
 		},
 	}, {
-		name: "workspace mount path already in stepTemplate",
-		fields: fields{
-			StepTemplate: &v1.StepTemplate{
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/foo",
-				}},
-			},
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:      "some-workspace",
-				MountPath: "/foo",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/foo\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
-		},
-	}, {
-		name: "workspace default mount path already in stepTemplate",
-		fields: fields{
-			StepTemplate: &v1.StepTemplate{
-				VolumeMounts: []corev1.VolumeMount{{
-					Name:      "my-mount",
-					MountPath: "/workspace/some-workspace",
-				}},
-			},
-			Steps: validSteps,
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name: "some-workspace",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
-			Paths:   []string{"workspaces[0].mountpath"},
-		},
-	}, {
-		name: "result name not valid",
-		fields: fields{
-			Steps: validSteps,
-			Results: []v1.TaskResult{{
-				Name:        "MY^RESULT",
-				Description: "my great result",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `invalid key name "MY^RESULT"`,
-			Paths:   []string{"results[0].name"},
-			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
-		},
-	}, {
-		name: "result type not valid",
-		fields: fields{
-			Steps: validSteps,
-			Results: []v1.TaskResult{{
-				Name:        "MY-RESULT",
-				Type:        "wrong",
-				Description: "my great result",
-			}},
-		},
-		expectedError: apis.FieldError{
-			Message: `invalid value: wrong`,
-			Paths:   []string{"results[0].type"},
-			Details: "type must be string",
-		},
-	}, {
-		name: "context not valid",
+		name: "invalid step - invalid onError usage - set to a parameter which does not exist in the task",
 		fields: fields{
 			Steps: []v1.Step{{
-				Image: "my-image",
-				Args:  []string{"arg"},
-				Script: `
-				#!/usr/bin/env  bash
-				hello "$(context.task.missing)"`,
+				OnError: "$(params.CONTINUE)",
+				Image:   "image",
+				Args:    []string{"arg"},
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
-			Paths:   []string{"steps[0].script"},
+			Message: "non-existent variable in \"$(params.CONTINUE)\"",
+			Paths:   []string{"spec.steps[0].onError"},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := v1.TaskSpec{
-				Params:       tt.fields.Params,
-				Steps:        tt.fields.Steps,
-				Volumes:      tt.fields.Volumes,
-				StepTemplate: tt.fields.StepTemplate,
-				Workspaces:   tt.fields.Workspaces,
-				Results:      tt.fields.Results,
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: v1.TaskSpec{
+					Params:     tt.fields.Params,
+					Steps:      tt.fields.Steps,
+					Sidecars:   tt.fields.Sidecars,
+					Workspaces: tt.fields.Workspaces,
+				},
 			}
 			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
+			task.SetDefaults(ctx)
+			err := task.Validate(ctx)
 			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
+				t.Fatalf("Expected an error, got nothing for %v", task)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -1364,881 +1645,4499 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}
 }
 
-func TestStepAndSidecarWorkspaces(t *testing.T) {
-	type fields struct {
-		Steps      []v1.Step
-		Sidecars   []v1.Sidecar
-		Workspaces []v1.WorkspaceDeclaration
-	}
-	tests := []struct {
-		name   string
-		fields fields
-	}{{
-		name: "valid step workspace usage",
-		fields: fields{
-			Steps: []v1.Step{{
-				Image: "my-image",
-				Args:  []string{"arg"},
-				Workspaces: []v1.WorkspaceUsage{{
-					Name:      "foo-workspace",
-					MountPath: "/a/custom/mountpath",
-				}},
-			}},
-			Workspaces: []v1.WorkspaceDeclaration{{
-				Name:        "foo-workspace",
-				Description: "my great workspace",
-				MountPath:   "some/path",
-			}},
-		},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps:      tt.fields.Steps,
-				Sidecars:   tt.fields.Sidecars,
-				Workspaces: tt.fields.Workspaces,
-			}
-			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
-			}
-		})
-	}
-}
-
-func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
+func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
-		Steps    []v1.Step
-		Sidecars []v1.Sidecar
+		Params       []v1.ParamSpec
+		Steps        []v1.Step
+		Sidecars     []v1.Sidecar
+		Volumes      []corev1.Volume
+		StepTemplate *v1.StepTemplate
+		Workspaces   []v1.WorkspaceDeclaration
+		Results      []v1.TaskResult
 	}
 	tests := []struct {
 		name          string
+		policy        *config.ValidationPolicy
 		fields        fields
 		expectedError apis.FieldError
 	}{{
-		name: "step workspace that refers to non-existent workspace declaration fails",
+		name: "empty spec",
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "no step",
 		fields: fields{
-			Steps: []v1.Step{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
 			}},
 		},
 		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"steps[0].workspaces[0].name"},
+			Message: `missing field(s)`,
+			Paths:   []string{"steps"},
 		},
 	}, {
-		name: "sidecar workspace that refers to non-existent workspace declaration fails",
+		name: "step script refers to nonexistent result",
 		fields: fields{
 			Steps: []v1.Step{{
-				Image: "foo",
+				Name:  "step-name",
+				Image: "my-image",
+				Script: `
+				#!/usr/bin/env bash
+				date | tee $(results.non-exist.path)`,
+				Args: []string{"$(results.a-result.path)"},
 			}},
-			Sidecars: []v1.Sidecar{{
-				Image: "foo",
-				Workspaces: []v1.WorkspaceUsage{{
-					Name: "foo",
-				}},
+			Results: []v1.TaskResult{{Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env bash\n\t\t\t\tdate | tee $(results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "invalid param name format",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "_validparam1",
+				Description: "valid param name format",
+			}, {
+				Name:        "valid_param2",
+				Description: "valid param name format",
+			}, {
+				Name:        "",
+				Description: "invalid param name format",
+			}, {
+				Name:        "a^b",
+				Description: "invalid param name format",
+			}, {
+				Name:        "0ab",
+				Description: "invalid param name format",
+			}, {
+				Name:        "f oo",
+				Description: "invalid param name format",
+			}},
+			Steps: validSteps,
+		},
+		expectedError: *(&apis.FieldError{
+			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
+			Paths:   []string{"params"},
+			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
+		}).Also(apis.ErrInvalidValue("", "params[].name")).
+			Also(apis.ErrInvalidValue("0ab", "params[0ab].name")).
+			Also(apis.ErrInvalidValue("a^b", "params[a^b].name")).
+			Also(apis.ErrInvalidValue("f oo", "params[f oo].name")),
+	}, {
+		name: "invalid object param format - object param name and key name shouldn't contain dots.",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "invalid.name1",
+				Description: "object param name contains dots",
+				Properties: map[string]v1.PropertySpec{
+					"invalid.key1": {},
+					"mykey2":       {},
+				},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: *(&apis.FieldError{
+			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
+				"invalid.name1": {"invalid.key1"},
+			}),
+			Paths:   []string{"params"},
+			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
+		}).Also(apis.ErrInvalidValue("invalid.name1", "params[invalid.name1].name")).
+			Also(apis.ErrInvalidValue("invalid.key1", "params[invalid.name1].properties[invalid.key1]")),
+	}, {
+		name: "duplicated param names",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("value1"),
+			}, {
+				Name:        "foo",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("value2"),
 			}},
+			Steps: validSteps,
 		},
 		expectedError: apis.FieldError{
-			Message: `undefined workspace "foo"`,
-			Paths:   []string{"sidecars[0].workspaces[0].name"},
+			Message: `parameter appears more than once`,
+			Paths:   []string{"params[foo]"},
 		},
-	}}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps:    tt.fields.Steps,
-				Sidecars: tt.fields.Sidecars,
-			}
+	}, {
+		name: "invalid param type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
+			}, {
+				Name:        "param-with-invalid-type",
+				Type:        "invalidtype",
+				Description: "invalidtypedesc",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalidtype`,
+			Paths:   []string{"params.param-with-invalid-type.type"},
+		},
+	}, {
+		name: "param mismatching default/type 1",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 2",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeString,
+				Description: "param",
+				Default:     v1.NewStructuredValues("default", "array"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"string" type does not match default value's type: "array"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 3",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeArray,
+				Description: "param",
+				Default: v1.NewObject(map[string]string{
+					"key1": "var1",
+					"key2": "var2",
+				}),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "object"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "param mismatching default/type 4",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties:  map[string]v1.PropertySpec{"key1": {}},
+				Default:     v1.NewStructuredValues("var"),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"object" type does not match default value's type: "string"`,
+			Paths:   []string{"params.task.type", "params.task.default.type"},
+		},
+	}, {
+		name: "PropertySpec type is set with unsupported type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "task",
+				Type:        v1.ParamTypeObject,
+				Description: "param",
+				Properties: map[string]v1.PropertySpec{
+					"key1": {Type: "number"},
+					"key2": {Type: "string"},
+				},
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("The value type specified for these keys %v is invalid", []string{"key1"}),
+			Paths:   []string{"params.task.properties"},
+		},
+	}, {
+		name: "invalid step",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "validparam",
+				Type:        v1.ParamTypeString,
+				Description: "parameter",
+				Default:     v1.NewStructuredValues("default"),
+			}},
+			Steps: []v1.Step{},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps"},
+		},
+	}, {
+		name: "duplicate step name",
+		fields: fields{
+			Steps: []v1.Step{
+				{Name: "mystep", Image: "myimage"},
+				{Name: "mystep", Image: "myimage"},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"steps[1].name"},
+		},
+	}, {
+		name: "array used in a string field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz)",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used in a string field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "$(params.baz[*])",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"$(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "array star used illegally in script field",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{
+				{
+					Script:     "$(params.baz[*])",
+					Name:       "mystep",
+					Image:      "my-image",
+					WorkingDir: "/foo/bar/src/",
+				},
+			},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.baz[*])"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "array param used in step env value field that can accept string type",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "my-image",
+				Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.baz)"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `env value "$(params.baz)" cannot reference an entire array param, reference an individual element with $(params.<name>[i]) instead`,
+			Paths:   []string{"steps[0].env[URL]"},
+		},
+	}, {
+		name: "array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "baz",
+				Type: v1.ParamTypeArray,
+			}, {
+				Name: "foo-is-baz",
+				Type: v1.ParamTypeArray,
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz)", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inferred array star not properly isolated",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}, {
+				Name:    "foo-is-baz",
+				Default: v1.NewStructuredValues("implied", "array", "type"),
+			}},
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "someimage",
+				Command:    []string{"$(params.foo-is-baz)"},
+				Args:       []string{"not isolated: $(params.baz[*])", "middle string", "url"},
+				WorkingDir: "/foo/bar/src/",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "not isolated: $(params.baz[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "Multiple volumes with same name",
+		fields: fields{
+			Steps: validSteps,
+			Volumes: []corev1.Volume{{
+				Name: "workspace",
+			}, {
+				Name: "workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `multiple volumes with same name "workspace"`,
+			Paths:   []string{"volumes[1].name"},
+		},
+	}, {
+		name: "declared workspaces names are not unique",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "same-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "same-workspace",
+				MountPath: "/bar",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace name \"same-workspace\" must be unique",
+			Paths:   []string{"workspaces[1].name"},
+		},
+	}, {
+		name: "declared workspaces clash with each other",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}, {
+				Name:      "another-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[1].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in volumeMounts",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image:   "myimage",
+				Command: []string{"command"},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace/",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/foo",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "some-workspace",
+				MountPath: "/foo",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/foo\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "workspace default mount path already in stepTemplate",
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "my-mount",
+					MountPath: "/workspace/some-workspace",
+				}},
+			},
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name: "some-workspace",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "workspace mount path \"/workspace/some-workspace\" must be unique",
+			Paths:   []string{"workspaces[0].mountpath"},
+		},
+	}, {
+		name: "result name not valid",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"/tekton/results/MY^RESULT"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "MY^RESULT",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid key name "MY^RESULT"`,
+			Paths:   []string{"results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}, {
+		name: "result type not valid",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.MY-RESULT.path)"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "MY-RESULT",
+				Type:        "wrong",
+				Description: "my great result",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: wrong`,
+			Paths:   []string{"results[0].type"},
+			Details: "type must be string",
+		},
+	}, {
+		name: "context not valid",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Script: `
+				#!/usr/bin/env  bash
+				hello "$(context.task.missing)"`,
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t\t#!/usr/bin/env  bash\n\t\t\t\thello \"$(context.task.missing)\""`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "non-deterministic taskRun uid in workspace mountPath",
+		fields: fields{
+			Steps: validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:      "cache",
+				MountPath: "/cache/$(context.taskRun.uid)",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-deterministic variable $(context.taskRun.uid) used in "/cache/$(context.taskRun.uid)"`,
+			Paths:   []string{"workspaces[0].mountPath"},
+			Level:   apis.WarningLevel,
+		},
+	}, {
+		name: "non-deterministic taskRun name in array result value",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:  "res",
+				Type:  v1.ResultsTypeArray,
+				Value: &v1.ParamValue{Type: v1.ParamTypeString, ArrayVal: []string{"$(context.taskRun.name)"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-deterministic variable $(context.taskRun.name) used in "$(context.taskRun.name)"`,
+			Paths:   []string{"results[0].value[0]"},
+			Level:   apis.WarningLevel,
+		},
+	}, {
+		name: "non-deterministic taskRun uid in object result value",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:       "res",
+				Type:       v1.ResultsTypeObject,
+				Properties: map[string]v1.PropertySpec{"id": {Type: v1.ParamTypeString}},
+				Value:      &v1.ParamValue{Type: v1.ParamTypeString, ObjectVal: map[string]string{"id": "$(context.taskRun.uid)"}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-deterministic variable $(context.taskRun.uid) used in "$(context.taskRun.uid)"`,
+			Paths:   []string{"results[0].value[id]"},
+			Level:   apis.WarningLevel,
+		},
+	}, {
+		name: "continue step result not consumed",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:    "producer",
+				Image:   "my-image",
+				Args:    []string{"$(step.results.out.path)"},
+				OnError: v1.Continue,
+				Results: []v1.StepResult{{Name: "out"}},
+			}, {
+				Name:  "other",
+				Image: "my-image",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `result "out" of step with onError "continue" is not consumed by any later step or Task result`,
+			Paths:   []string{"steps[0].results[0]"},
+			Level:   apis.WarningLevel,
+		},
+	}, {
+		name: "step result apparently not written",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:    "writer",
+				Image:   "my-image",
+				Script:  "date | tee $(step.results.out.path)",
+				Results: []v1.StepResult{{Name: "out"}, {Name: "unwritten"}},
+			}},
+		},
+		expectedError: *apis.ErrGeneric(`result "unwritten" is not referenced by the step and may never be written`, "steps[0].results[1]").At(apis.WarningLevel),
+	}, {
+		name: "duplicate step when expressions",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				When: v1.StepWhenExpressions{{
+					Input:    "foo",
+					Operator: selection.In,
+					Values:   []string{"foo"},
+				}, {
+					Input:    "bar",
+					Operator: selection.In,
+					Values:   []string{"bar"},
+				}, {
+					Input:    "foo",
+					Operator: selection.In,
+					Values:   []string{"foo"},
+				}},
+			}},
+		},
+		expectedError: *apis.ErrGeneric("when expression is identical to when expression 0", "steps[0].when[2]").At(apis.WarningLevel),
+	}, {
+		name: "string param default referencing another param",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "foo",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewStructuredValues("$(params.bar)"),
+			}, {
+				Name: "bar",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: *apis.ErrGeneric(`default value "$(params.bar)" references another param, which will not be resolved`, "params.foo.default").At(apis.WarningLevel),
+	}, {
+		name: "array param default referencing another param",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:    "foo",
+				Type:    v1.ParamTypeArray,
+				Default: v1.NewStructuredValues("baz", "prefix-$(params.bar)"),
+			}, {
+				Name: "bar",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: *apis.ErrGeneric(`default value "prefix-$(params.bar)" references another param, which will not be resolved`, "params.foo.default").At(apis.WarningLevel),
+	}, {
+		name: "task result produced by an undeclared step",
+		fields: fields{
+			Steps: validSteps,
+			Results: []v1.TaskResult{{
+				Name:  "out",
+				Value: v1.NewStructuredValues("$(steps.producer.results.out)"),
+			}},
+		},
+		expectedError: *apis.ErrGeneric(`task result "out" references step "producer", which is not declared`, "results[0].value"),
+	}, {
+		name: "task result produced by an undeclared step result",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:    "producer",
+				Image:   "myimage",
+				Script:  "date | tee $(step.results.other.path)",
+				Results: []v1.StepResult{{Name: "other"}},
+			}},
+			Results: []v1.TaskResult{{
+				Name:  "out",
+				Value: v1.NewStructuredValues("$(steps.producer.results.out)"),
+			}},
+		},
+		expectedError: *apis.ErrGeneric(`task result "out" references result "out" of step "producer", which the step does not declare`, "results[0].value"),
+	}, {
+		name: "task result not written by any step",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:   "reader",
+				Image:  "myimage",
+				Script: "cat /workspace/out",
+			}},
+			Results: []v1.TaskResult{{Name: "out"}},
+		},
+		expectedError: *apis.ErrGeneric(`task result "out" is not written by any step and may never be produced`, "results[0]").At(apis.WarningLevel),
+	}, {
+		name: "task result only written by a step guarded by when expressions",
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:   "guarded",
+				Image:  "myimage",
+				Script: "date | tee $(results.out.path)",
+				When:   v1.StepWhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+			}},
+			Results: []v1.TaskResult{{Name: "out"}},
+		},
+		expectedError: *apis.ErrGeneric(`task result "out" is only written by steps guarded by when expressions and may not be produced`, "results[0]").At(apis.WarningLevel),
+	}, {
+		name: "object param JSON string default with undeclared key",
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:       "repo",
+				Type:       v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{"url": {}},
+				Default:    v1.NewStructuredValues(`{"url": "https://github.com/tektoncd/pipeline", "branch": "main"}`),
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `default value key "branch" is not declared in the properties of object param`,
+			Paths:   []string{"params.repo.default"},
+		},
+	}, {
+		name:   "unnamed step rejected by the require-step-names policy",
+		policy: &config.ValidationPolicy{RequireStepNames: true},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "named",
+				Image: "myimage",
+			}, {
+				Image: "myimage",
+			}},
+		},
+		expectedError: *apis.ErrMissingField("steps[1].name"),
+	}, {
+		name:   "missing step limits rejected by the require-step-resource-limits policy",
+		policy: &config.ValidationPolicy{RequireStepResourceLimits: true},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "limited",
+				Image: "myimage",
+				ComputeResources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				}},
+			}, {
+				Name:  "unlimited",
+				Image: "myimage",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"steps[1].computeResources.limits.cpu", "steps[1].computeResources.limits.memory"},
+			Details: `validation policy "require-step-resource-limits" requires steps to declare CPU and memory limits`,
+		},
+	}, {
+		name:   "workspaces over the max-workspace-count limit",
+		policy: &config.ValidationPolicy{MaxWorkspaceCount: 2},
+		fields: fields{
+			Steps:      validSteps,
+			Workspaces: []v1.WorkspaceDeclaration{{Name: "workspace-0"}, {Name: "workspace-1"}, {Name: "workspace-2"}},
+		},
+		expectedError: apis.FieldError{
+			Message: "a Task can declare at most 2 workspaces, got 3",
+			Paths:   []string{"workspaces"},
+		},
+	}, {
+		name:   "param name not matching the param-name-pattern policy",
+		policy: &config.ValidationPolicy{ParamNamePattern: "[a-z][a-z0-9-]*"},
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "git-url",
+				Type: v1.ParamTypeString,
+			}, {
+				Name: "gitRevision",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: validSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `param name "gitRevision" does not match the pattern "[a-z][a-z0-9-]*" required by the "param-name-pattern" validation policy`,
+			Paths:   []string{"params[gitRevision].name"},
+		},
+	}, {
+		name:   "step privilege escalation rejected by the deny-privilege-escalation policy",
+		policy: &config.ValidationPolicy{DenyPrivilegeEscalation: true},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:            "mystep",
+				Image:           "myimage",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(true)},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: true",
+			Paths:   []string{"steps[0].securityContext.allowPrivilegeEscalation"},
+			Details: `validation policy "deny-privilege-escalation" requires allowPrivilegeEscalation to be false or unset`,
+		},
+	}, {
+		name:   "sidecar privilege escalation rejected by the deny-privilege-escalation policy",
+		policy: &config.ValidationPolicy{DenyPrivilegeEscalation: true},
+		fields: fields{
+			Steps: validSteps,
+			Sidecars: []v1.Sidecar{{
+				Name:            "mysidecar",
+				Image:           "myimage",
+				SecurityContext: &corev1.SecurityContext{AllowPrivilegeEscalation: pointer.Bool(true)},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: true",
+			Paths:   []string{"sidecars[0].securityContext.allowPrivilegeEscalation"},
+			Details: `validation policy "deny-privilege-escalation" requires allowPrivilegeEscalation to be false or unset`,
+		},
+	}, {
+		name:   "image registry not allowed by the allowed-image-registries policy",
+		policy: &config.ValidationPolicy{AllowedImageRegistries: "gcr.io,localhost:5000,docker.io"},
+		fields: fields{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "quay.io/org/image@sha256:1234",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `registry "quay.io" of image "quay.io/org/image@sha256:1234" is not allowed by the "allowed-image-registries" validation policy`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name:   "default docker.io registry not allowed by the allowed-image-registries policy",
+		policy: &config.ValidationPolicy{AllowedImageRegistries: "gcr.io"},
+		fields: fields{
+			StepTemplate: &v1.StepTemplate{Image: "ubuntu"},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "gcr.io/project/image",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:  "sidecar",
+				Image: "index.docker.io/library/busybox",
+			}},
+		},
+		expectedError: *(&apis.FieldError{
+			Message: `registry "docker.io" of image "index.docker.io/library/busybox" is not allowed by the "allowed-image-registries" validation policy`,
+			Paths:   []string{"sidecars[0].image"},
+		}).Also(&apis.FieldError{
+			Message: `registry "docker.io" of image "ubuntu" is not allowed by the "allowed-image-registries" validation policy`,
+			Paths:   []string{"stepTemplate.image"},
+		}),
+	}, {
+		name:   "descriptions over the max-description-length limit",
+		policy: &config.ValidationPolicy{MaxDescriptionLength: 10},
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name:        "param",
+				Type:        v1.ParamTypeString,
+				Description: strings.Repeat("a", 11),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.result.path)"},
+			}},
+			Results: []v1.TaskResult{{
+				Name:        "result",
+				Description: strings.Repeat("a", 11),
+			}},
+		},
+		expectedError: *(&apis.FieldError{
+			Message: "description is 11 bytes long, longer than the maximum of 10 bytes",
+			Paths:   []string{"params.param.description"},
+		}).Also(&apis.FieldError{
+			Message: "description is 11 bytes long, longer than the maximum of 10 bytes",
+			Paths:   []string{"results[0].description"},
+		}),
+	}, {
+		name:   "missing descriptions rejected by the require-descriptions policy",
+		policy: &config.ValidationPolicy{RequireDescriptions: true},
+		fields: fields{
+			Params: []v1.ParamSpec{{
+				Name: "param",
+				Type: v1.ParamTypeString,
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(results.result.path)"},
+			}},
+			Results: []v1.TaskResult{{Name: "result"}},
+		},
+		expectedError: *(&apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"params.param.description"},
+			Details: `validation policy "require-descriptions" requires results and params to declare a description`,
+		}).Also(&apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"results[0].description"},
+			Details: `validation policy "require-descriptions" requires results and params to declare a description`,
+		}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := v1.TaskSpec{
+				Params:       tt.fields.Params,
+				Steps:        tt.fields.Steps,
+				Sidecars:     tt.fields.Sidecars,
+				Volumes:      tt.fields.Volumes,
+				StepTemplate: tt.fields.StepTemplate,
+				Workspaces:   tt.fields.Workspaces,
+				Results:      tt.fields.Results,
+			}
+			ctx := withValidationPolicy(cfgtesting.EnableAlphaAPIFields(t.Context()), tt.policy)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			// The errors are compared field by field, so that their paths and level are checked as well.
+			if d := cmp.Diff(tt.expectedError.WrappedErrors(), err.WrappedErrors(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspaces(t *testing.T) {
+	type fields struct {
+		Steps      []v1.Step
+		Sidecars   []v1.Sidecar
+		Workspaces []v1.WorkspaceDeclaration
+	}
+	tests := []struct {
+		name   string
+		fields fields
+	}{{
+		name: "valid step workspace usage",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Workspaces: []v1.WorkspaceUsage{{
+					Name:      "foo-workspace",
+					MountPath: "/a/custom/mountpath",
+				}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{
+				Name:        "foo-workspace",
+				Description: "my great workspace",
+				MountPath:   "/some/path",
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:      tt.fields.Steps,
+				Sidecars:   tt.fields.Sidecars,
+				Workspaces: tt.fields.Workspaces,
+			}
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
+	type fields struct {
+		Steps    []v1.Step
+		Sidecars []v1.Sidecar
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		expectedError apis.FieldError
+	}{{
+		name: "step workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"steps[0].workspaces[0].name"},
+		},
+	}, {
+		name: "sidecar workspace that refers to non-existent workspace declaration fails",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "foo",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Image: "foo",
+				Workspaces: []v1.WorkspaceUsage{{
+					Name: "foo",
+				}},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `undefined workspace "foo"`,
+			Paths:   []string{"sidecars[0].workspaces[0].name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.fields.Steps,
+				Sidecars: tt.fields.Sidecars,
+			}
+
+			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestIncompatibleAPIVersions exercises validation of fields that
+// require a specific feature gate version in order to work.
+func TestIncompatibleAPIVersions(t *testing.T) {
+	versions := []string{"alpha", "beta", "stable"}
+	isStricterThen := func(first, second string) bool {
+		// assume values are in order alpha (less strict), beta, stable (strictest)
+		// return true if first is stricter then second
+		switch first {
+		case second, "alpha":
+			return false
+		case "stable":
+			return true
+		default:
+			// first is beta, true is second is alpha, false is second is stable
+			return second == "alpha"
+		}
+	}
+
+	for _, tt := range []struct {
+		name            string
+		requiredVersion string
+		spec            v1.TaskSpec
+	}{
+		{
+			name:            "step workspace requires beta",
+			requiredVersion: "beta",
+			spec: v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name: "foo",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Workspaces: []v1.WorkspaceUsage{{
+						Name: "foo",
+					}},
+				}},
+			},
+		}, {
+			name:            "sidecar workspace requires beta",
+			requiredVersion: "beta",
+			spec: v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{
+					Name: "foo",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+				}},
+				Sidecars: []v1.Sidecar{{
+					Image: "foo",
+					Workspaces: []v1.WorkspaceUsage{{
+						Name: "foo",
+					}},
+				}},
+			},
+		}, {
+			name:            "integer param requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "count",
+					Type:    v1.ParamTypeInteger,
+					Default: v1.NewStructuredValues("3"),
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Args:  []string{"$(params.count)"},
+				}},
+			},
+		}, {
+			name:            "param allowed pattern requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:           "flag",
+					AllowedPattern: "--[a-z-]+",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Args:  []string{"$(params.flag)"},
+				}},
+			},
+		}, {
+			name:            "param minItems and maxItems require alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:     "flags",
+					Type:     v1.ParamTypeArray,
+					MinItems: 1,
+					MaxItems: 2,
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Args:  []string{"$(params.flags[*])"},
+				}},
+			},
+		}, {
+			name:            "param format requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:   "url",
+					Type:   v1.ParamTypeString,
+					Format: "uri",
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Args:  []string{"$(params.url)"},
+				}},
+			},
+		}, {
+			name:            "array of objects param requires alpha",
+			requiredVersion: "alpha",
+			spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:       "items",
+					Type:       v1.ParamTypeArray,
+					Properties: map[string]v1.PropertySpec{"name": {Type: v1.ParamTypeString}},
+				}},
+				Steps: []v1.Step{{
+					Image: "foo",
+					Args:  []string{"$(params.items[0].name)"},
+				}},
+			},
+		},
+	} {
+		for _, version := range versions {
+			testName := fmt.Sprintf("(using %s) %s", version, tt.name)
+			t.Run(testName, func(t *testing.T) {
+				ts := tt.spec
+				ctx := t.Context()
+				if version == "alpha" {
+					ctx = cfgtesting.EnableAlphaAPIFields(ctx)
+				}
+				if version == "beta" {
+					ctx = cfgtesting.EnableBetaAPIFields(ctx)
+				}
+				if version == "stable" {
+					ctx = cfgtesting.EnableStableAPIFields(ctx)
+				}
+				ts.SetDefaults(ctx)
+				err := ts.Validate(ctx)
+
+				// If the configured version is stricter than the required one, we expect an error
+				if isStricterThen(version, tt.requiredVersion) && err == nil {
+					t.Fatalf("no error received even though version required is %q while feature gate is %q", tt.requiredVersion, version)
+				}
+
+				// If the configured version is more permissive than the required one, we expect no error
+				if isStricterThen(tt.requiredVersion, version) && err != nil {
+					t.Fatalf("error received despite required version and feature gate matching %q: %v", version, err)
+				}
+			})
+		}
+	}
+}
+
+func TestTaskSpecValidateUsageOfDeclaredParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		Params        []v1.ParamSpec
+		Steps         []v1.Step
+		expectedError apis.FieldError
+	}{{
+		name: "inexistent param variable",
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"--flag=$(params.inexistent)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object used in a string field",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "$(params.gitrepo)",
+			Args:       []string{"echo"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object star used in a string field",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "$(params.gitrepo[*])",
+			Args:       []string{"echo"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].image"},
+		},
+	}, {
+		name: "object used in a field that can accept array type",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "myimage",
+			Args:       []string{"$(params.gitrepo)"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object star used in a field that can accept array type",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "some-git-image",
+			Args:       []string{"$(params.gitrepo[*])"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.gitrepo[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object param used in step env value field that can accept string type",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "do-the-clone",
+			Image: "some-git-image",
+			Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.gitrepo)"}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `env value "$(params.gitrepo)" cannot reference an entire object param, reference an individual key with $(params.<name>.<key>) instead`,
+			Paths:   []string{"steps[0].env[URL]"},
+		},
+	}, {
+		name: "non-existent individual key of an object param is used in task step",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url":    {},
+				"commit": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:       "do-the-clone",
+			Image:      "some-git-image",
+			Args:       []string{"$(params.gitrepo.non-exist-key)"},
+			WorkingDir: "/foo/bar/src/",
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "inexistent param variable in volumeMount with existing",
+		Params: []v1.ParamSpec{
+			{
+				Name:        "foo",
+				Description: "param",
+				Default:     v1.NewStructuredValues("default"),
+			},
+		},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			VolumeMounts: []corev1.VolumeMount{{
+				Name: "$(params.inexistent)-foo",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.inexistent)-foo"`,
+			Paths:   []string{"steps[0].volumeMounts[0].name"},
+		},
+	}, {
+		name: "inexistent param variable with existing",
+		Params: []v1.ParamSpec{{
+			Name:        "foo",
+			Description: "param",
+			Default:     v1.NewStructuredValues("default"),
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"$(params.foo) && $(params.inexistent)"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "object used as a whole in an env value",
+		Params: []v1.ParamSpec{{
+			Name: "config",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Env: []corev1.EnvVar{{
+				Name:  "CONFIG",
+				Value: "$(params.config)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `env value "$(params.config)" cannot reference an entire object param, reference an individual key with $(params.<name>.<key>) instead`,
+			Paths:   []string{"steps[0].env[CONFIG]"},
+		},
+	}, {
+		name: "object used as a whole embedded in an env value",
+		Params: []v1.ParamSpec{{
+			Name: "config",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Env: []corev1.EnvVar{{
+				Name:  "CONFIG",
+				Value: "prefix-$(params.config)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `env value "prefix-$(params.config)" cannot reference an entire object param, reference an individual key with $(params.<name>.<key>) instead`,
+			Paths:   []string{"steps[0].env[CONFIG]"},
+		},
+	}, {
+		name: "non-existent object key referenced with bracket notation",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{`$(params.gitrepo["commit"])`},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.gitrepo[\"commit\"])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "entire object param used in step when input",
+		Params: []v1.ParamSpec{{
+			Name: "config",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"mode": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			When: v1.StepWhenExpressions{{
+				Input:    "$(params.config)",
+				Operator: selection.In,
+				Values:   []string{"foo"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "$(params.config)"`,
+			Paths:   []string{"steps[0].when[0].input"},
+		},
+	}, {
+		name: "object param variable with non-existent properties",
+		Params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: v1.ParamTypeObject,
+		}},
+		Steps: validSteps,
+		expectedError: apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"foo.properties"},
+		},
+	}, {
+		name: "object key referenced with single quote bracket notation",
+		Params: []v1.ParamSpec{{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{
+				"url": {},
+			},
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"$(params.gitrepo['url'])"},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.gitrepo['url'])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "key of string param referenced with bracket notation",
+		Params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}},
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{`$(params.foo["key"])`},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.foo[\"key\"])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name: "key of array param referenced with bracket notation",
+		Params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: v1.ParamTypeArray,
+		}},
+		Steps: []v1.Step{{
+			Name:   "mystep",
+			Image:  "myimage",
+			Script: `echo $(params.foo["key"])`,
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo $(params.foo[\"key\"])"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v1.ValidateUsageOfDeclaredParameters(t.Context(), tt.Steps, tt.Params)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidateUsageOfDeclaredParams_ObjectKeyBrackets(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "gitrepo",
+		Type: v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{
+			"url":    {},
+			"commit": {},
+		},
+	}}
+	steps := []v1.Step{{
+		Name:  "mystep",
+		Image: "myimage",
+		Args:  []string{`$(params.gitrepo["url"])`, `--commit=$(params.gitrepo["commit"])`},
+	}}
+	if err := v1.ValidateUsageOfDeclaredParameters(t.Context(), steps, params); err != nil {
+		t.Errorf("ValidateUsageOfDeclaredParameters() returned error for bracket references to declared keys: %v", err)
+	}
+}
+
+func TestGetArrayIndexParamRefs(t *testing.T) {
+	stepsReferences := []string{}
+	for i := 10; i <= 26; i++ {
+		stepsReferences = append(stepsReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+	}
+	volumesReferences := []string{}
+	for i := 10; i <= 22; i++ {
+		volumesReferences = append(volumesReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+	}
+
+	tcs := []struct {
+		name     string
+		taskspec *v1.TaskSpec
+		want     sets.String
+	}{
+		{
+			name: "steps reference",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Steps: []v1.Step{{
+					Name:    "$(params.array-params[10])",
+					Image:   "$(params.array-params[11])",
+					Command: []string{"$(params.array-params[12])"},
+					Args:    []string{"$(params.array-params[13])"},
+					Script:  "echo $(params.array-params[14])",
+					Env: []corev1.EnvVar{{
+						Value: "$(params.array-params[15])",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								Key: "$(params.array-params[16])",
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[17])",
+								},
+							},
+							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+								Key: "$(params.array-params[18])",
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[19])",
+								},
+							},
+						},
+					}},
+					EnvFrom: []corev1.EnvFromSource{{
+						Prefix: "$(params.array-params[20])",
+						ConfigMapRef: &corev1.ConfigMapEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "$(params.array-params[21])",
+							},
+						},
+						SecretRef: &corev1.SecretEnvSource{
+							LocalObjectReference: corev1.LocalObjectReference{
+								Name: "$(params.array-params[22])",
+							},
+						},
+					}},
+					WorkingDir: "$(params.array-params[23])",
+					VolumeMounts: []corev1.VolumeMount{{
+						Name:      "$(params.array-params[24])",
+						MountPath: "$(params.array-params[25])",
+						SubPath:   "$(params.array-params[26])",
+					}},
+				}},
+				StepTemplate: &v1.StepTemplate{
+					Image: "$(params.array-params[27])",
+				},
+			},
+			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
+				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
+				"$(params.array-params[21])", "$(params.array-params[22])", "$(params.array-params[23])", "$(params.array-params[24])", "$(params.array-params[25])", "$(params.array-params[26])", "$(params.array-params[27])"),
+		}, {
+			name: "stepTemplate reference",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				StepTemplate: &v1.StepTemplate{
+					Image: "$(params.array-params[3])",
+				},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		}, {
+			name: "volumes references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Volumes: []corev1.Volume{
+					{
+						Name: "$(params.array-params[10])",
+						VolumeSource: corev1.VolumeSource{
+							ConfigMap: &corev1.ConfigMapVolumeSource{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "$(params.array-params[11])",
+								},
+								Items: []corev1.KeyToPath{
+									{
+										Key:  "$(params.array-params[12])",
+										Path: "$(params.array-params[13])",
+									},
+								},
+							},
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: "$(params.array-params[14])",
+								Items: []corev1.KeyToPath{{
+									Key:  "$(params.array-params[15])",
+									Path: "$(params.array-params[16])",
+								}},
+							},
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "$(params.array-params[17])",
+							},
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{{
+									ConfigMap: &corev1.ConfigMapProjection{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "$(params.array-params[18])",
+										},
+									},
+									Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{
+											Name: "$(params.array-params[19])",
+										},
+									},
+									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+										Audience: "$(params.array-params[20])",
+									},
+								}},
+							},
+							CSI: &corev1.CSIVolumeSource{
+								NodePublishSecretRef: &corev1.LocalObjectReference{
+									Name: "$(params.array-params[21])",
+								},
+								VolumeAttributes: map[string]string{"key": "$(params.array-params[22])"},
+							},
+						},
+					},
+				},
+			},
+			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
+				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
+				"$(params.array-params[21])", "$(params.array-params[22])"),
+		}, {
+			name: "workspaces references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Workspaces: []v1.WorkspaceDeclaration{{
+					MountPath: "$(params.array-params[3])",
+				}},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		}, {
+			name: "sidecar references",
+			taskspec: &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:    "array-params",
+					Default: v1.NewStructuredValues("bar", "foo"),
+				}},
+				Sidecars: []v1.Sidecar{
+					{
+						Script: "$(params.array-params[3])",
+					},
+				},
+			},
+			want: sets.NewString("$(params.array-params[3])"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.taskspec.GetIndexingReferencesToArrayParams()
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GetIndexingReferencesToArrayParams diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamEnum_Success(t *testing.T) {
+	tcs := []struct {
+		name      string
+		params    v1.ParamSpecs
+		configMap map[string]string
+	}{{
+		name: "valid param enum - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}, {
+			Name: "param2",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}},
+	}, {
+		name: "valid param enum with literal values containing a dollar sign - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"$HOME", "price-$5"},
+		}},
+	}, {
+		name: "valid empty param enum - success",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "param2",
+			Type: v1.ParamTypeString,
+		}},
+	}}
+
+	for _, tc := range tcs {
+		cfg := map[string]string{"enable-param-enum": "true"}
+		ctx := cfgtesting.SetFeatureFlags(t.Context(), t, cfg)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+		if err != nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		}
+	}
+}
+
+func TestParamEnum_Failure(t *testing.T) {
+	tcs := []struct {
+		name        string
+		params      v1.ParamSpecs
+		configMap   map[string]string
+		expectedErr error
+	}{{
+		name: "param default val not in enum list - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Default: &v1.ParamValue{
+				Type:      v1.ParamTypeString,
+				StringVal: "v4",
+			},
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("param default value v4 not in the enum list: params[param1]"),
+	}, {
+		name: "param enum with array type - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeArray,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
+	}, {
+		name: "param enum with object type - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeObject,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
+	}, {
+		name: "param enum with duplicate values - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
+	}, {
+		name: "param enum with variable reference - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "$(params.param2)"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "true",
+		},
+		expectedErr: errors.New(`parameter enum value "$(params.param2)" cannot reference variables: params[param1].enum`),
+	}, {
+		name: "param enum with feature flag disabled - failure",
+		params: []v1.ParamSpec{{
+			Name: "param1",
+			Type: v1.ParamTypeString,
+			Enum: []string{"v1", "v2"},
+		}},
+		configMap: map[string]string{
+			"enable-param-enum": "false",
+		},
+		expectedErr: errors.New("feature flag `enable-param-enum` should be set to true to use Enum: params[param1]"),
+	}}
+
+	for _, tc := range tcs {
+		ctx := cfgtesting.SetFeatureFlags(t.Context(), t, tc.configMap)
+
+		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
+
+		if err == nil {
+			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
+		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
+			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestTaskSpecValidate_StepResults(t *testing.T) {
+	type fields struct {
+		Image   string
+		Args    []string
+		Script  string
+		Results []v1.StepResult
+	}
+	tests := []struct {
+		name   string
+		fields fields
+	}{{
+		name: "valid result",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"$(step.results.MY-RESULT.path)"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type string",
+		fields: fields{
+			Image:  "my-image",
+			Args:   []string{"arg"},
+			Script: "echo $(step.results.MY-RESULT.path)",
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        "string",
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type array",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"$(step.results.MY-RESULT.path)"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        v1.ResultsTypeArray,
+				Description: "my great result",
+			}},
+		},
+	}, {
+		name: "valid result type object",
+		fields: fields{
+			Image: "my-image",
+			Args:  []string{"$(step.results.MY-RESULT.path)"},
+			Results: []v1.StepResult{{
+				Name:        "MY-RESULT",
+				Type:        v1.ResultsTypeObject,
+				Description: "my great result",
+				Properties: map[string]v1.PropertySpec{
+					"url":    {Type: "string"},
+					"commit": {Type: "string"},
+				},
+			}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   tt.fields.Image,
+					Args:    tt.fields.Args,
+					Script:  tt.fields.Script,
+					Results: tt.fields.Results,
+				}},
+			}
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{},
+			})
+			ts.SetDefaults(ctx)
+			if err := ts.Validate(ctx); err != nil {
+				t.Errorf("TaskSpec.Validate() = %v", err)
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepResults_Error(t *testing.T) {
+	type fields struct {
+		Image   string
+		Script  string
+		Results []v1.StepResult
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		expectedError   apis.FieldError
+		isCreate        bool
+		isUpdate        bool
+		baselineTaskRun *v1.TaskRun
+	}{{
+		name: "step script refers to nonexistent result",
+		fields: fields{
+			Image: "my-image",
+			Script: `
+			#!/usr/bin/env bash
+			date | tee $(results.non-exist.path)`,
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step script refers to nonexistent stepresult",
+		fields: fields{
+			Image: "my-image",
+			Script: `
+			#!/usr/bin/env bash
+			date | tee $(step.results.non-exist.path)`,
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(step.results.non-exist.path)"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name: "step script refers to a stepresult with the steps prefix",
+		fields: fields{
+			Image:   "my-image",
+			Script:  "date | tee $(steps.results.a-result.path)",
+			Results: []v1.StepResult{{Name: "a-result"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `$(steps.results.a-result.path) is not a valid step result reference`,
+			Paths:   []string{"steps[0].script"},
+			Details: "use $(step.results.a-result.path) to write the result of the current step, or $(steps.<stepName>.results.a-result) to read the result of an earlier step",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Image:   tt.fields.Image,
+					Script:  tt.fields.Script,
+					Results: tt.fields.Results,
+				}},
+			}
+			ctx := t.Context()
+			if tt.isCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tt.isUpdate {
+				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Filter(apis.ErrorLevel).Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhen_Error(t *testing.T) {
+	tests := []struct {
+		name            string
+		ts              *v1.TaskSpec
+		isCreate        bool
+		Results         []v1.StepResult
+		isUpdate        bool
+		baselineTaskRun *v1.TaskRun
+		expectedError   apis.FieldError
+		EnableCEL       bool
+	}{{
+		name: "cel not allowed if EnableCELInWhenExpression is false",
+		ts: &v1.TaskSpec{Steps: []v1.Step{{
+			Image: "my-image",
+			When:  v1.StepWhenExpressions{{CEL: "'d'=='d'"}},
+		}}},
+		expectedError: apis.FieldError{
+			Message: `feature flag enable-cel-in-whenexpression should be set to true to use CEL: 'd'=='d' in WhenExpression`,
+			Paths:   []string{"steps[0].when[0]"},
+		},
+	},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{
+					EnableCELInWhenExpression: tt.EnableCEL,
+				},
+			})
+			if tt.isCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			if tt.isUpdate {
+				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
+			}
+			tt.ts.SetDefaults(ctx)
+			err := tt.ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhenStyles(t *testing.T) {
+	tests := []struct {
+		name          string
+		when          v1.StepWhenExpressions
+		expectedError string
+	}{{
+		name: "operator style only",
+		when: v1.StepWhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"foo"}}},
+	}, {
+		name: "cel style only",
+		when: v1.StepWhenExpressions{{CEL: "'foo' == 'foo'"}},
+	}, {
+		name: "both styles in one entry",
+		when: v1.StepWhenExpressions{{
+			CEL:      "'foo' == 'foo'",
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+		expectedError: "cel and input+operator+values cannot be set in one WhenExpression: steps[0].when[0]",
+	}, {
+		name:          "cel syntax error",
+		when:          v1.StepWhenExpressions{{CEL: "'foo' == "}},
+		expectedError: "invalid cel expression: 'foo' ==  with err: ",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{EnableCELInWhenExpression: true},
+			})
+			ts := &v1.TaskSpec{Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "my-image",
+				When:  tt.when,
+			}}}
+			err := ts.Validate(ctx)
+			switch {
+			case tt.expectedError == "" && err != nil:
+				t.Errorf("TaskSpec.Validate() = %v, want no error", err)
+			case tt.expectedError != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.expectedError)):
+				t.Errorf("TaskSpec.Validate() = %v, want an error starting with %q", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ResultNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		resultName    string
+		expectedError *apis.FieldError
+	}{{
+		name:       "valid result name",
+		resultName: "my.result-1",
+	}, {
+		name:       "valid result name starting with a digit",
+		resultName: "1st_result",
+	}, {
+		name:       "result name with a space",
+		resultName: "my result",
+		expectedError: &apis.FieldError{
+			Message: `invalid key name "my result"`,
+			Paths:   []string{"results[0].name"},
+			Details: "Name must consist of alphanumeric characters, '-', '_', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my-name',  or 'my_name', regex used for validation is '^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$')",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					Args:  []string{"/tekton/results/" + tt.resultName},
+				}},
+				Results: []v1.TaskResult{{Name: tt.resultName}},
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_RequireExplicitParamTypes(t *testing.T) {
+	tests := []struct {
+		name          string
+		requireTypes  bool
+		params        []v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name:         "untyped param allowed when policy is disabled",
+		requireTypes: false,
+		params:       []v1.ParamSpec{{Name: "foo"}},
+	}, {
+		name:         "typed params allowed when policy is enabled",
+		requireTypes: true,
+		params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "bar",
+			Type: v1.ParamTypeArray,
+		}},
+	}, {
+		name:         "untyped param rejected when policy is enabled",
+		requireTypes: true,
+		params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: v1.ParamTypeString,
+		}, {
+			Name:    "bar",
+			Default: v1.NewStructuredValues("baz"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: "missing field(s)",
+			Paths:   []string{"params.bar.type"},
+			Details: `validation policy "require-explicit-param-types" requires params to explicitly declare their type`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					RequireExplicitParamTypes: tt.requireTypes,
+				},
+			})
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps:  validSteps,
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateNameFormat_PerNamePaths(t *testing.T) {
+	stringAndArrayParams := sets.NewString("valid", "0ab", "f oo")
+	objectParams := []v1.ParamSpec{{
+		Name: "gitrepo",
+		Type: v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{
+			"url":     {Type: v1.ParamTypeString},
+			"bad.key": {Type: v1.ParamTypeString},
+		},
+	}, {
+		Name: "bad.obj",
+		Type: v1.ParamTypeObject,
+	}}
+	wantPaths := []string{
+		"params",
+		"params[0ab].name",
+		"params[bad.obj].name",
+		"params[f oo].name",
+		"params[gitrepo].properties[bad.key]",
+	}
+
+	err := v1.ValidateNameFormat(stringAndArrayParams, objectParams)
+	gotPaths := sets.NewString()
+	for _, e := range err.WrappedErrors() {
+		gotPaths.Insert(e.Paths...)
+	}
+	if d := cmp.Diff(wantPaths, gotPaths.List()); d != "" {
+		t.Errorf("ValidateNameFormat() paths diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateParameterVariables_ReportsAllErrors(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "foo",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "foo",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "bad,name",
+		Type: v1.ParamTypeString,
+	}}
+	steps := []v1.Step{{
+		Name:  "first",
+		Image: "$(params.foo)",
+	}, {
+		Name:       "second",
+		Image:      "myimage",
+		WorkingDir: "$(params.foo)",
+	}}
+	expectedErr := errors.New(`The format of following array and string variable names is invalid: [bad,name]: params
+String/Array Names: 
+Must only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)
+Must begin with a letter or an underscore (_)
+invalid value: bad,name: params[bad,name].name
+parameter appears more than once: params[foo]
+variable type invalid in "$(params.foo)": steps[0].image, steps[1].workingDir`)
+
+	err := v1.ValidateParameterVariables(t.Context(), steps, params)
+	if err == nil {
+		t.Fatalf("Expected errors from ValidateParameterVariables() but got none")
+	}
+	if d := cmp.Diff(expectedErr.Error(), err.Error()); d != "" {
+		t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateParameterVariables_AllParamTypes(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "str",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "bad,count",
+		Type: v1.ParamTypeInteger,
+	}, {
+		Name: "arr",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name:       "obj",
+		Type:       v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{"key": {Type: v1.ParamTypeString}},
+	}}
+	steps := []v1.Step{{
+		Name:  "mystep",
+		Image: "myimage",
+		Args:  []string{"$(params.str)", "$(params[\"bad,count\"])", "$(params.arr[*])", "$(params.obj.key)"},
+	}, {
+		Name:       "other",
+		Image:      "myimage",
+		WorkingDir: "$(params.arr)",
+	}}
+	// The integer param is checked like a string param, and the array param like an array param.
+	expectedErr := errors.New(`The format of following array and string variable names is invalid: [bad,count]: params
+String/Array Names: 
+Must only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)
+Must begin with a letter or an underscore (_)
+invalid value: bad,count: params[bad,count].name
+variable type invalid in "$(params.arr)": steps[1].workingDir`)
+
+	err := v1.ValidateParameterVariables(cfgtesting.EnableAlphaAPIFields(t.Context()), steps, params)
+	if d := cmp.Diff(expectedErr.Error(), err.Error()); d != "" {
+		t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestParamSpecValidateType_Integer(t *testing.T) {
+	tests := []struct {
+		name          string
+		paramSpec     v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name:      "no default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger},
+	}, {
+		name:      "integer default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("3")},
+	}, {
+		name:      "negative integer default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("-3")},
+	}, {
+		name:      "max int64 default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("9223372036854775807")},
+	}, {
+		name:      "min int64 default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("-9223372036854775808")},
+	}, {
+		name:      "fractional default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("3.5")},
+		expectedError: &apis.FieldError{
+			Message: `default value "3.5" of integer param must not have a fractional part`,
+			Paths:   []string{"count.default"},
+		},
+	}, {
+		name:      "out of range default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("9223372036854775808")},
+		expectedError: &apis.FieldError{
+			Message: `default value "9223372036854775808" of integer param is not a valid integer`,
+			Paths:   []string{"count.default"},
+		},
+	}, {
+		name:      "non numeric default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("three")},
+		expectedError: &apis.FieldError{
+			Message: `default value "three" of integer param is not a valid integer`,
+			Paths:   []string{"count.default"},
+		},
+	}, {
+		name:      "array default",
+		paramSpec: v1.ParamSpec{Name: "count", Type: v1.ParamTypeInteger, Default: v1.NewStructuredValues("1", "2")},
+		expectedError: &apis.FieldError{
+			Message: `"integer" type does not match default value's type: "array"`,
+			Paths:   []string{"count.type", "count.default.type"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.paramSpec.ValidateType(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ParamSpec.ValidateType() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestParamSpecValidateType_ObjectDefaultString(t *testing.T) {
+	properties := map[string]v1.PropertySpec{
+		"url":    {Type: v1.ParamTypeString},
+		"commit": {Type: v1.ParamTypeString},
+	}
+	tests := []struct {
+		name          string
+		paramSpec     v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name:      "valid JSON object",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": "https://github.com/tektoncd/pipeline", "commit": "main"}`)},
+	}, {
+		name:      "malformed JSON",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": `)},
+		expectedError: &apis.FieldError{
+			Message: `default value "{\"url\": " of object param is not a valid JSON object`,
+			Paths:   []string{"repo.default"},
+			Details: "unexpected end of JSON input",
+		},
+	}, {
+		name:      "non-string JSON values",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": ["a", "b"]}`)},
+		expectedError: &apis.FieldError{
+			Message: `default value "{\"url\": [\"a\", \"b\"]}" of object param is not a valid JSON object`,
+			Paths:   []string{"repo.default"},
+			Details: "json: cannot unmarshal array into Go struct field .url of type string",
+		},
+	}, {
+		name:      "plain string",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues("main")},
+		expectedError: &apis.FieldError{
+			Message: `"object" type does not match default value's type: "string"`,
+			Paths:   []string{"repo.type", "repo.default.type"},
+		},
+	}, {
+		name:      "keys not in properties",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": "https://github.com/tektoncd/pipeline", "branch": "main", "tag": "v1"}`)},
+		expectedError: apis.ErrGeneric(`default value key "branch" is not declared in the properties of object param`, "repo.default").
+			Also(apis.ErrGeneric(`default value key "tag" is not declared in the properties of object param`, "repo.default")),
+	}, {
+		name:          "duplicate keys",
+		paramSpec:     v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": "https://github.com/tektoncd/pipeline", "commit": "main", "url": "https://github.com/tektoncd/triggers"}`)},
+		expectedError: apis.ErrGeneric(`default value key "url" is declared more than once in the JSON object of object param`, "repo.default"),
+	}, {
+		name:      "same key in a nested JSON string value",
+		paramSpec: v1.ParamSpec{Name: "repo", Type: v1.ParamTypeObject, Properties: properties, Default: v1.NewStructuredValues(`{"url": "{\"url\": 1}", "commit": "main"}`)},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.paramSpec.ValidateType(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ParamSpec.ValidateType() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+		// Defaulting converts a valid JSON string default to its structured form, which must be validated the same way.
+		t.Run(tt.name+" after defaulting", func(t *testing.T) {
+			ps := tt.paramSpec
+			ps.SetDefaults(t.Context())
+			err := ps.ValidateType(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ParamSpec.ValidateType() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ArrayWholeAndStarUsage(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "flags",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "other",
+		Type: v1.ParamTypeArray,
+	}}
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "consistent star usage",
+		steps: []v1.Step{{
+			Name:    "build",
+			Image:   "myimage",
+			Command: []string{"$(params.flags[*])"},
+		}, {
+			Name:  "test",
+			Image: "myimage",
+			Args:  []string{"$(params.flags[*])", "$(params.other)"},
+		}},
+	}, {
+		name: "whole and star usage in the same step",
+		steps: []v1.Step{{
+			Name:    "build",
+			Image:   "myimage",
+			Command: []string{"$(params.flags)"},
+			Args:    []string{"$(params.flags[*])"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `array param "flags" is referenced both as $(params.flags) and as $(params.flags[*]), reference it consistently as $(params.flags[*])`,
+			Paths:   []string{"steps[0]"},
+		},
+	}, {
+		name: "whole and star usage in different steps",
+		steps: []v1.Step{{
+			Name:  "build",
+			Image: "myimage",
+			Args:  []string{"$(params.flags[*])"},
+		}, {
+			Name:  "test",
+			Image: "myimage",
+			Args:  []string{"$(params.flags)"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `array param "flags" is referenced both as $(params.flags) and as $(params.flags[*]), reference it consistently as $(params.flags[*])`,
+			Paths:   []string{"steps[1]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: params,
+				Steps:  tt.steps,
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ArrayOfObjects(t *testing.T) {
+	properties := map[string]v1.PropertySpec{
+		"name":  {Type: v1.ParamTypeString},
+		"value": {Type: v1.ParamTypeString},
+	}
+	tests := []struct {
+		name          string
+		param         v1.ParamSpec
+		args          []string
+		expectedError *apis.FieldError
+	}{{
+		name: "valid default list",
+		param: v1.ParamSpec{
+			Name:       "items",
+			Type:       v1.ParamTypeArray,
+			Properties: properties,
+			Default:    v1.NewStructuredValues(`{"name": "a", "value": "1"}`, `{"name": "b", "value": "2"}`),
+		},
+		args: []string{"$(params.items[0].name)=$(params.items[1].value)", "$(params.items[*])"},
+	}, {
+		name: "element missing a key",
+		param: v1.ParamSpec{
+			Name:       "items",
+			Type:       v1.ParamTypeArray,
+			Properties: properties,
+			Default:    v1.NewStructuredValues(`{"name": "a", "value": "1"}`, `{"name": "b"}`),
+		},
+		expectedError: apis.ErrGeneric(`default value element is missing the key "value" declared in the properties of array of objects param`, "params.items.default[1]"),
+	}, {
+		name: "element with an undeclared key and an element which is not an object",
+		param: v1.ParamSpec{
+			Name:       "items",
+			Type:       v1.ParamTypeArray,
+			Properties: properties,
+			Default:    v1.NewStructuredValues(`{"name": "a", "value": "1", "type": "x"}`, "b"),
+		},
+		expectedError: apis.ErrGeneric(`default value element key "type" is not declared in the properties of array of objects param`, "params.items.default[0]").
+			Also(apis.ErrGeneric(`default value element "b" of array of objects param is not a valid JSON object`, "params.items.default[1]")),
+	}, {
+		name: "reference to an undeclared key",
+		param: v1.ParamSpec{
+			Name:       "items",
+			Type:       v1.ParamTypeArray,
+			Properties: properties,
+		},
+		args:          []string{"$(params.items[0].type)"},
+		expectedError: apis.ErrGeneric(`$(params.items[0].type) references the key "type", which is not declared in the properties of array of objects param "items"`, "steps[0]"),
+	}, {
+		name: "reference to a key of an element of an array of strings",
+		param: v1.ParamSpec{
+			Name: "items",
+			Type: v1.ParamTypeArray,
+		},
+		args:          []string{"$(params.items[0].name)"},
+		expectedError: apis.ErrGeneric(`$(params.items[0].name) references the key "name" of an element of array param "items", which declares no properties`, "steps[0]"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					Args:  tt.args,
+				}},
+			}
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhenArrayUsage(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "arr",
+		Type: v1.ParamTypeArray,
+	}, {
+		Name: "str",
+		Type: v1.ParamTypeString,
+	}}
+	tests := []struct {
+		name          string
+		when          v1.StepWhenExpressions
+		expectedError *apis.FieldError
+	}{{
+		name: "whole array param used in input",
+		when: v1.StepWhenExpressions{{
+			Input:    "$(params.arr)",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.arr)"`,
+			Paths:   []string{"steps[0].when[0].input"},
+		},
+	}, {
+		name: "array param star reference used in input",
+		when: v1.StepWhenExpressions{{
+			Input:    "$(params.arr[*])",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "$(params.arr[*])"`,
+			Paths:   []string{"steps[0].when[0].input"},
+		},
+	}, {
+		name: "indexed array param used in input",
+		when: v1.StepWhenExpressions{{
+			Input:    "$(params.arr[0])",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+	}, {
+		name: "string param used in input",
+		when: v1.StepWhenExpressions{{
+			Input:    "$(params.str)",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+	}, {
+		name: "whole array param expanded into values",
+		when: v1.StepWhenExpressions{{
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"$(params.arr[*])"},
+		}},
+	}, {
+		name: "whole array param embedded in a value",
+		when: v1.StepWhenExpressions{{
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"prefix-$(params.arr[*])"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "prefix-$(params.arr[*])"`,
+			Paths:   []string{"steps[0].when[0].values[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				When:  tt.when,
+			}}
+			err := v1.ValidateParameterVariables(t.Context(), steps, params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateParameterVariables_AmbiguousReferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		args          []string
+		expectedError *apis.FieldError
+	}{{
+		name: "dotted param name referenced with the dot notation next to its prefix",
+		params: []v1.ParamSpec{{
+			Name: "config",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "config.key",
+			Type: v1.ParamTypeString,
+		}},
+		args: []string{"$(params.config)", "$(params.config.key)"},
+		expectedError: &apis.FieldError{
+			Message: `$(params.config.key) may refer to the param "config.key" or to the key "key" of the param "config", reference it as $(params["config.key"]) instead`,
+			Paths:   []string{"params[config.key].name"},
+			Level:   apis.WarningLevel,
+		},
+	}, {
+		name: "dotted param name referenced with the bracket notation",
+		params: []v1.ParamSpec{{
+			Name: "config",
+			Type: v1.ParamTypeString,
+		}, {
+			Name: "config.key",
+			Type: v1.ParamTypeString,
+		}},
+		args: []string{"$(params.config)", `$(params["config.key"])`},
+	}, {
+		name: "object param key",
+		params: []v1.ParamSpec{{
+			Name:       "config",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"key": {Type: v1.ParamTypeString}},
+		}},
+		args: []string{"$(params.config.key)"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  tt.args,
+			}}
+			err := v1.ValidateParameterVariables(t.Context(), steps, tt.params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
 
-			ctx := cfgtesting.EnableAlphaAPIFields(t.Context())
-			ts.SetDefaults(ctx)
+func TestTaskSpecValidate_StepEnvArrayUsage(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "arr",
+		Type: v1.ParamTypeArray,
+	}}
+	tests := []struct {
+		name          string
+		env           []corev1.EnvVar
+		expectedError *apis.FieldError
+	}{{
+		name: "whole array param in env value",
+		env:  []corev1.EnvVar{{Name: "ARR", Value: "$(params.arr)"}},
+		expectedError: &apis.FieldError{
+			Message: `env value "$(params.arr)" cannot reference an entire array param, reference an individual element with $(params.<name>[i]) instead`,
+			Paths:   []string{"steps[0].env[ARR]"},
+		},
+	}, {
+		name: "whole array param star reference in env value",
+		env:  []corev1.EnvVar{{Name: "ARR", Value: "$(params.arr[*])"}},
+		expectedError: &apis.FieldError{
+			Message: `env value "$(params.arr[*])" cannot reference an entire array param, reference an individual element with $(params.<name>[i]) instead`,
+			Paths:   []string{"steps[0].env[ARR]"},
+		},
+	}, {
+		name: "whole array param star reference embedded in env value",
+		env:  []corev1.EnvVar{{Name: "ARR", Value: "items: $(params.arr[*])"}},
+		expectedError: &apis.FieldError{
+			Message: `env value "items: $(params.arr[*])" cannot reference an entire array param, reference an individual element with $(params.<name>[i]) instead`,
+			Paths:   []string{"steps[0].env[ARR]"},
+		},
+	}, {
+		name: "indexed array param in env value",
+		env:  []corev1.EnvVar{{Name: "FIRST", Value: "$(params.arr[0])"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Env:   tt.env,
+			}}
+			err := v1.ValidateParameterVariables(t.Context(), steps, params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ArrayFanOutPositions(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "items",
+		Type: v1.ParamTypeArray,
+	}}
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
+	}{{
+		name: "whole array in step command and args",
+		steps: []v1.Step{{
+			Name:    "mystep",
+			Image:   "myimage",
+			Command: []string{"$(params.items[*])"},
+			Args:    []string{"--", "$(params.items[*])"},
+		}},
+	}, {
+		name: "whole array in step when values",
+		steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			When:  v1.StepWhenExpressions{{Input: "foo", Operator: selection.In, Values: []string{"$(params.items[*])"}}},
+		}},
+	}, {
+		name:  "whole array in sidecar args",
+		steps: validSteps,
+		sidecars: []v1.Sidecar{{
+			Name:  "server",
+			Image: "my-image",
+			Args:  []string{"$(params.items[*])"},
+		}},
+	}, {
+		name:  "whole array in sidecar script",
+		steps: validSteps,
+		sidecars: []v1.Sidecar{{
+			Name:   "server",
+			Image:  "my-image",
+			Script: "serve $(params.items[*])",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "serve $(params.items[*])"`,
+			Paths:   []string{"sidecars[0].script"},
+		},
+	}, {
+		name:  "whole array embedded in sidecar args",
+		steps: validSteps,
+		sidecars: []v1.Sidecar{{
+			Name:  "server",
+			Image: "my-image",
+			Args:  []string{"--items=$(params.items[*])"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "--items=$(params.items[*])"`,
+			Paths:   []string{"sidecars[0].args[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params:   params,
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateUsageOfDeclaredParameters_ScriptObjectUsageAsWhole(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name:       "obj",
+		Type:       v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{"key": {}},
+	}}
+	tests := []struct {
+		name          string
+		script        string
+		apiFields     string
+		expectedError *apis.FieldError
+	}{{
+		name:      "star reference to whole object in script",
+		script:    "echo '$(params.obj[*])' | jq .key",
+		apiFields: config.AlphaAPIFields,
+	}, {
+		name:      "bare reference to whole object in script",
+		script:    "echo '$(params.obj)' | jq .key",
+		apiFields: config.AlphaAPIFields,
+		expectedError: &apis.FieldError{
+			Message: `variable "obj" must be referenced as "$(params.obj[*])" to be expanded as JSON in "echo '$(params.obj)' | jq .key"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name:      "star reference to whole object in script without alpha",
+		script:    "echo '$(params.obj[*])' | jq .key",
+		apiFields: config.BetaAPIFields,
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "echo '$(params.obj[*])' | jq .key"`,
+			Paths:   []string{"steps[0].script"},
+		},
+	}, {
+		name:      "object key reference in script",
+		script:    "echo $(params.obj.key)",
+		apiFields: config.AlphaAPIFields,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{EnableAPIFields: tt.apiFields},
+			})
+			steps := []v1.Step{{
+				Name:   "mystep",
+				Image:  "myimage",
+				Script: tt.script,
+			}}
+			err := v1.ValidateUsageOfDeclaredParameters(ctx, steps, params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateUsageOfDeclaredParameters() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_AllowedParamTypes(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "foo",
+		Type: v1.ParamTypeString,
+	}, {
+		Name: "gitrepo",
+		Type: v1.ParamTypeObject,
+		Properties: map[string]v1.PropertySpec{
+			"url": {Type: v1.ParamTypeString},
+		},
+	}}
+	tests := []struct {
+		name          string
+		allowedTypes  string
+		expectedError *apis.FieldError
+	}{{
+		name:         "all types allowed by default",
+		allowedTypes: "",
+	}, {
+		name:         "object type allowed",
+		allowedTypes: "string,object",
+	}, {
+		name:         "object type disallowed",
+		allowedTypes: "string,array",
+		expectedError: &apis.FieldError{
+			Message: `param type "object" is not allowed, allowed types are "string,array"`,
+			Paths:   []string{"params.gitrepo.type"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					AllowedParamTypes: tt.allowedTypes,
+				},
+			})
+			ts := &v1.TaskSpec{
+				Params: params,
+				Steps:  validSteps,
+			}
 			err := ts.Validate(ctx)
-			if err == nil {
-				t.Fatalf("Expected an error, got nothing for %v", ts)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_MissingSteps(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name: "foo",
+			Type: "invalid",
+		}},
+		StepTemplate: &v1.StepTemplate{
+			Image: "myimage",
+		},
+		Results: []v1.TaskResult{{
+			Name:  "digest",
+			Type:  v1.ResultsTypeString,
+			Value: v1.NewStructuredValues("$(steps.build.results.digest)"),
+		}},
+	}
+	// The results referencing the missing steps are not reported, only that they cannot be produced.
+	expectedError := apis.ErrMissingField("steps").Also(apis.ErrInvalidValue("invalid", "params.foo.type")).
+		Also(apis.ErrGeneric("results are declared but there are no steps to produce them", "results"))
+
+	err := ts.Validate(t.Context())
+	if d := cmp.Diff(expectedError.Error(), err.Error()); d != "" {
+		t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestTaskSpecValidate_ResourceRequestsWithinLimits(t *testing.T) {
+	tests := []struct {
+		name          string
+		resources     corev1.ResourceRequirements
+		expectedError *apis.FieldError
+	}{{
+		name: "requests within limits",
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1024Mi"),
+			},
+		},
+	}, {
+		name: "limits without requests",
+		resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+	}, {
+		name: "requests exceed limits",
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+		expectedError: apis.ErrGeneric("cpu request 2 exceeds the limit 1500m", "steps[0].computeResources").
+			Also(apis.ErrGeneric("memory request 2Gi exceeds the limit 1Gi", "steps[0].computeResources")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:             "mystep",
+					Image:            "myimage",
+					ComputeResources: tt.resources,
+				}},
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ScriptSize(t *testing.T) {
+	script := func(size int) string {
+		return strings.Repeat("#", size)
+	}
+	tests := []struct {
+		name          string
+		scripts       []string
+		expectedError *apis.FieldError
+	}{{
+		name:    "scripts at the limits",
+		scripts: []string{script(100), script(100)},
+	}, {
+		name:    "step script exceeding the limit",
+		scripts: []string{script(101)},
+		expectedError: &apis.FieldError{
+			Message: "script size of 101 bytes exceeds the maximum of 100 bytes",
+			Paths:   []string{"steps[0].script"},
+			Details: "consider mounting the script from a workspace instead of declaring it inline",
+		},
+	}, {
+		name:    "combined scripts exceeding the limit",
+		scripts: []string{script(100), script(100), script(1)},
+		expectedError: &apis.FieldError{
+			Message: "combined script size of 201 bytes exceeds the maximum of 200 bytes",
+			Paths:   []string{"steps"},
+			Details: "consider mounting the scripts from a workspace instead of declaring them inline",
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{
+					MaxStepScriptSize:  100,
+					MaxTotalScriptSize: 200,
+				},
+			})
+			ts := &v1.TaskSpec{}
+			for i, s := range tt.scripts {
+				ts.Steps = append(ts.Steps, v1.Step{
+					Name:   fmt.Sprintf("step-%d", i),
+					Image:  "myimage",
+					Script: s,
+				})
+			}
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_StepWhenResultsOrder(t *testing.T) {
+	when := func(stepName string) v1.StepWhenExpressions {
+		return v1.StepWhenExpressions{{
+			Input:    fmt.Sprintf("$(steps.%s.results.out)", stepName),
+			Operator: selection.In,
+			Values:   []string{"yes"},
+		}}
+	}
+	tests := []struct {
+		name          string
+		firstWhen     v1.StepWhenExpressions
+		secondWhen    v1.StepWhenExpressions
+		expectedError *apis.FieldError
+	}{{
+		name:       "reference to an earlier step",
+		secondWhen: when("first"),
+	}, {
+		name:      "reference to a later step",
+		firstWhen: when("second"),
+		expectedError: &apis.FieldError{
+			Message: `when expression references the results of step "second", which does not run before it`,
+			Paths:   []string{"steps[0].when[0]"},
+		},
+	}, {
+		name:       "reference to the same step",
+		secondWhen: when("second"),
+		expectedError: &apis.FieldError{
+			Message: `when expression references the results of step "second", which does not run before it`,
+			Paths:   []string{"steps[1].when[0]"},
+		},
+	}, {
+		name: "reference to a result not declared by an earlier step",
+		secondWhen: v1.StepWhenExpressions{{
+			Input:    "$(steps.first.results.missing)",
+			Operator: selection.In,
+			Values:   []string{"yes"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: `when expression references result "missing" of step "first", which the step does not declare`,
+			Paths:   []string{"steps[1].when[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:    "first",
+					Image:   "myimage",
+					Script:  "echo -n yes > $(step.results.out.path)",
+					Results: []v1.StepResult{{Name: "out"}},
+					When:    tt.firstWhen,
+				}, {
+					Name:    "second",
+					Image:   "myimage",
+					Script:  "echo -n yes > $(step.results.out.path)",
+					Results: []v1.StepResult{{Name: "out"}},
+					When:    tt.secondWhen,
+				}},
+			}
+			err := ts.Validate(t.Context()).Filter(apis.ErrorLevel)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_MaxResultCount(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxResultCount int
+		resultCount    int
+		expectedError  *apis.FieldError
+	}{{
+		name:           "no limit",
+		maxResultCount: 0,
+		resultCount:    3,
+	}, {
+		name:           "below the limit",
+		maxResultCount: 2,
+		resultCount:    1,
+	}, {
+		name:           "at the limit",
+		maxResultCount: 2,
+		resultCount:    2,
+	}, {
+		name:           "above the limit",
+		maxResultCount: 2,
+		resultCount:    3,
+		expectedError: &apis.FieldError{
+			Message: "a Task can declare at most 2 results, got 3",
+			Paths:   []string{"results"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags:     config.DefaultFeatureFlags.DeepCopy(),
+				ValidationPolicy: &config.ValidationPolicy{MaxResultCount: tt.maxResultCount},
+			})
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+				}},
+			}
+			for i := range tt.resultCount {
+				ts.Results = append(ts.Results, v1.TaskResult{Name: fmt.Sprintf("result-%d", i)})
+			}
+			err := ts.Validate(ctx).Filter(apis.ErrorLevel)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_EstimatedResultSize(t *testing.T) {
+	tests := []struct {
+		name             string
+		resultsFrom      string
+		descriptionSize  int
+		expectedWarnings *apis.FieldError
+	}{{
+		name:            "estimated size well below the termination message limit",
+		resultsFrom:     config.ResultExtractionMethodTerminationMessage,
+		descriptionSize: 100,
+	}, {
+		name:            "estimated size approaching the termination message limit",
+		resultsFrom:     config.ResultExtractionMethodTerminationMessage,
+		descriptionSize: 1700,
+		expectedWarnings: &apis.FieldError{
+			Message: "the estimated size of the results, 3470 bytes, approaches the termination message limit of 4096 bytes",
+			Paths:   []string{"results"},
+		},
+	}, {
+		name:            "results extracted from sidecar logs",
+		resultsFrom:     config.ResultExtractionMethodSidecarLogs,
+		descriptionSize: 1700,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{ResultExtractionMethod: tt.resultsFrom},
+			})
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:   "mystep",
+					Image:  "myimage",
+					Script: "echo foo | tee $(results.foo.path) && echo bar | tee $(results.bar.path)",
+				}},
+				Results: []v1.TaskResult{{
+					Name:        "foo",
+					Description: strings.Repeat("a", tt.descriptionSize),
+				}, {
+					Name:        "bar",
+					Description: strings.Repeat("b", tt.descriptionSize),
+				}},
+			}
+			err := ts.Validate(ctx).Filter(apis.WarningLevel)
+			if d := cmp.Diff(tt.expectedWarnings.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpec_RequiredAPIFields(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		ts   *v1.TaskSpec
+		want string
+	}{{
+		name: "stable features only",
+		ts: &v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name:       "obj",
+				Type:       v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{"key": {}},
+			}},
+			Steps: []v1.Step{{
+				Name:    "mystep",
+				Image:   "myimage",
+				Args:    []string{"$(params.obj.key)"},
+				Results: []v1.StepResult{{Name: "out"}},
+			}},
+		},
+		want: config.StableAPIFields,
+	}, {
+		name: "step workspaces",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:       "mystep",
+				Image:      "myimage",
+				Workspaces: []v1.WorkspaceUsage{{Name: "source"}},
+			}},
+			Workspaces: []v1.WorkspaceDeclaration{{Name: "source"}},
+		},
+		want: config.BetaAPIFields,
+	}, {
+		name: "step stdout stream",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:         "mystep",
+				Image:        "myimage",
+				StdoutConfig: &v1.StepOutputConfig{Path: "/data/stdout"},
+			}},
+		},
+		want: config.AlphaAPIFields,
+	}, {
+		name: "whole object param expanded in script",
+		ts: &v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name:       "obj",
+				Type:       v1.ParamTypeObject,
+				Properties: map[string]v1.PropertySpec{"key": {}},
+			}},
+			Steps: []v1.Step{{
+				Name:   "mystep",
+				Image:  "myimage",
+				Script: "echo '$(params.obj[*])'",
+			}},
+		},
+		want: config.AlphaAPIFields,
+	}, {
+		name: "stable features only with alpha enabled",
+		ctx:  cfgtesting.EnableAlphaAPIFields(t.Context()),
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+			}},
+		},
+		want: config.StableAPIFields,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = t.Context()
 			}
-
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			if got := tt.ts.RequiredAPIFields(ctx); got != tt.want {
+				t.Errorf("TaskSpec.RequiredAPIFields() = %q, want %q", got, tt.want)
 			}
 		})
 	}
 }
 
-// TestIncompatibleAPIVersions exercises validation of fields that
-// require a specific feature gate version in order to work.
-func TestIncompatibleAPIVersions(t *testing.T) {
-	versions := []string{"alpha", "beta", "stable"}
-	isStricterThen := func(first, second string) bool {
-		// assume values are in order alpha (less strict), beta, stable (strictest)
-		// return true if first is stricter then second
-		switch first {
-		case second, "alpha":
-			return false
-		case "stable":
-			return true
-		default:
-			// first is beta, true is second is alpha, false is second is stable
-			return second == "alpha"
-		}
-	}
-
-	for _, tt := range []struct {
-		name            string
-		requiredVersion string
-		spec            v1.TaskSpec
-	}{
-		{
-			name:            "step workspace requires beta",
-			requiredVersion: "beta",
-			spec: v1.TaskSpec{
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "foo",
-				}},
-				Steps: []v1.Step{{
-					Image: "foo",
-					Workspaces: []v1.WorkspaceUsage{{
-						Name: "foo",
-					}},
-				}},
-			},
+func TestTaskSpec_UsedContextVariables(t *testing.T) {
+	ts := &v1.TaskSpec{
+		DisplayName: "run $(context.taskRun.name)",
+		Steps: []v1.Step{{
+			Name:   "first",
+			Image:  "myimage",
+			Script: "echo $(context.task.retry-count) $(context.taskRun.name)",
 		}, {
-			name:            "sidecar workspace requires beta",
-			requiredVersion: "beta",
-			spec: v1.TaskSpec{
-				Workspaces: []v1.WorkspaceDeclaration{{
-					Name: "foo",
-				}},
-				Steps: []v1.Step{{
-					Image: "foo",
-				}},
-				Sidecars: []v1.Sidecar{{
-					Image: "foo",
-					Workspaces: []v1.WorkspaceUsage{{
-						Name: "foo",
-					}},
-				}},
-			},
-		},
-	} {
-		for _, version := range versions {
-			testName := fmt.Sprintf("(using %s) %s", version, tt.name)
-			t.Run(testName, func(t *testing.T) {
-				ts := tt.spec
-				ctx := t.Context()
-				if version == "alpha" {
-					ctx = cfgtesting.EnableAlphaAPIFields(ctx)
-				}
-				if version == "beta" {
-					ctx = cfgtesting.EnableBetaAPIFields(ctx)
-				}
-				if version == "stable" {
-					ctx = cfgtesting.EnableStableAPIFields(ctx)
-				}
-				ts.SetDefaults(ctx)
-				err := ts.Validate(ctx)
-
-				// If the configured version is stricter than the required one, we expect an error
-				if isStricterThen(version, tt.requiredVersion) && err == nil {
-					t.Fatalf("no error received even though version required is %q while feature gate is %q", tt.requiredVersion, version)
-				}
-
-				// If the configured version is more permissive than the required one, we expect no error
-				if isStricterThen(tt.requiredVersion, version) && err != nil {
-					t.Fatalf("error received despite required version and feature gate matching %q: %v", version, err)
-				}
-			})
-		}
+			Name: "second",
+			Env: []corev1.EnvVar{{
+				Name:  "NAMESPACE",
+				Value: "$(context.taskRun.namespace)",
+			}},
+			Image: "myimage",
+			Args:  []string{"$(params.foo)", "$(context.pipelineRun.name)"},
+		}},
+	}
+	want := []string{"context.task.retry-count", "context.taskRun.name", "context.taskRun.namespace"}
+	if d := cmp.Diff(want, ts.UsedContextVariables().List()); d != "" {
+		t.Errorf("UsedContextVariables() diff %s", diff.PrintWantGot(d))
+	}
+	if got := (&v1.TaskSpec{Steps: []v1.Step{{Image: "myimage"}}}).UsedContextVariables(); got.Len() != 0 {
+		t.Errorf("UsedContextVariables() = %v, want none", got.List())
 	}
 }
 
-func TestTaskSpecValidateUsageOfDeclaredParams(t *testing.T) {
+func TestTaskSpecValidate_SidecarPorts(t *testing.T) {
 	tests := []struct {
 		name          string
-		Params        []v1.ParamSpec
-		Steps         []v1.Step
-		expectedError apis.FieldError
+		sidecars      []v1.Sidecar
+		expectedError *apis.FieldError
 	}{{
-		name: "inexistent param variable",
-		Steps: []v1.Step{{
-			Name:  "mystep",
+		name: "distinct ports",
+		sidecars: []v1.Sidecar{{
+			Name:  "server",
 			Image: "myimage",
-			Args:  []string{"--flag=$(params.inexistent)"},
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 8443}},
+		}, {
+			Name:  "dns",
+			Image: "myimage",
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080, Protocol: corev1.ProtocolUDP}},
 		}},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "--flag=$(params.inexistent)"`,
-			Paths:   []string{"steps[0].args[0]"},
-		},
 	}, {
-		name: "object used in a string field",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "$(params.gitrepo)",
-			Args:       []string{"echo"},
-			WorkingDir: "/foo/bar/src/",
+		name: "duplicate port in a sidecar",
+		sidecars: []v1.Sidecar{{
+			Name:  "server",
+			Image: "myimage",
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}, {ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
 		}},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"steps[0].image"},
+		expectedError: &apis.FieldError{
+			Message: `port 8080/TCP is already exposed by sidecar "server"`,
+			Paths:   []string{"sidecars[0].ports[1]"},
 		},
 	}, {
-		name: "object star used in a string field",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "$(params.gitrepo[*])",
-			Args:       []string{"echo"},
-			WorkingDir: "/foo/bar/src/",
+		name: "duplicate port across sidecars",
+		sidecars: []v1.Sidecar{{
+			Name:  "server",
+			Image: "myimage",
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+		}, {
+			Name:  "proxy",
+			Image: "myimage",
+			Ports: []corev1.ContainerPort{{ContainerPort: 9090}, {ContainerPort: 8080}},
 		}},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"steps[0].image"},
+		expectedError: &apis.FieldError{
+			Message: `port 8080/TCP is already exposed by sidecar "server"`,
+			Paths:   []string{"sidecars[1].ports[1]"},
 		},
-	}, {
-		name: "object used in a field that can accept array type",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "myimage",
-			Args:       []string{"$(params.gitrepo)"},
-			WorkingDir: "/foo/bar/src/",
-		}},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"steps[0].args[0]"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+				}},
+				Sidecars: tt.sidecars,
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ParamPattern(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "all array default elements match",
+		param: v1.ParamSpec{
+			Name:           "flags",
+			Type:           v1.ParamTypeArray,
+			AllowedPattern: "--[a-z-]+",
+			Default:        v1.NewStructuredValues("--verbose", "--dry-run", "$(context.task.name)"),
 		},
 	}, {
-		name: "object star used in a field that can accept array type",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "some-git-image",
-			Args:       []string{"$(params.gitrepo[*])"},
-			WorkingDir: "/foo/bar/src/",
-		}},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo[*])"`,
-			Paths:   []string{"steps[0].args[0]"},
+		name: "one array default element does not match",
+		param: v1.ParamSpec{
+			Name:           "flags",
+			Type:           v1.ParamTypeArray,
+			AllowedPattern: "--[a-z-]+",
+			Default:        v1.NewStructuredValues("--verbose", "-v", "--dry-run"),
 		},
-	}, {
-		name: "object param used in step env value field that can accept string type",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:  "do-the-clone",
-			Image: "some-git-image",
-			Env:   []corev1.EnvVar{{Name: "URL", Value: "$(params.gitrepo)"}},
-		}},
-		expectedError: apis.FieldError{
-			Message: `variable type invalid in "$(params.gitrepo)"`,
-			Paths:   []string{"steps[0].env[URL]"},
+		expectedError: &apis.FieldError{
+			Message: `param default value "-v" does not match the allowed pattern "--[a-z-]+"`,
+			Paths:   []string{"params[flags].default[1]"},
 		},
 	}, {
-		name: "non-existent individual key of an object param is used in task step",
-		Params: []v1.ParamSpec{{
-			Name: "gitrepo",
-			Type: v1.ParamTypeObject,
-			Properties: map[string]v1.PropertySpec{
-				"url":    {},
-				"commit": {},
-			},
-		}},
-		Steps: []v1.Step{{
-			Name:       "do-the-clone",
-			Image:      "some-git-image",
-			Args:       []string{"$(params.gitrepo.non-exist-key)"},
-			WorkingDir: "/foo/bar/src/",
-		}},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
-			Paths:   []string{"steps[0].args[0]"},
+		name: "empty array default",
+		param: v1.ParamSpec{
+			Name:           "flags",
+			Type:           v1.ParamTypeArray,
+			AllowedPattern: "--[a-z-]+",
+			Default:        &v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{}},
 		},
 	}, {
-		name: "inexistent param variable in volumeMount with existing",
-		Params: []v1.ParamSpec{
-			{
-				Name:        "foo",
-				Description: "param",
-				Default:     v1.NewStructuredValues("default"),
-			},
+		name: "string default does not fully match",
+		param: v1.ParamSpec{
+			Name:           "flag",
+			Type:           v1.ParamTypeString,
+			AllowedPattern: "--[a-z-]+",
+			Default:        v1.NewStructuredValues("--verbose ; rm -rf /"),
 		},
-		Steps: []v1.Step{{
-			Name:  "mystep",
-			Image: "myimage",
-			VolumeMounts: []corev1.VolumeMount{{
-				Name: "$(params.inexistent)-foo",
-			}},
-		}},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.inexistent)-foo"`,
-			Paths:   []string{"steps[0].volumeMount[0].name"},
+		expectedError: &apis.FieldError{
+			Message: `param default value "--verbose ; rm -rf /" does not match the allowed pattern "--[a-z-]+"`,
+			Paths:   []string{"params[flag].default"},
 		},
 	}, {
-		name: "inexistent param variable with existing",
-		Params: []v1.ParamSpec{{
-			Name:        "foo",
-			Description: "param",
-			Default:     v1.NewStructuredValues("default"),
-		}},
-		Steps: []v1.Step{{
-			Name:  "mystep",
-			Image: "myimage",
-			Args:  []string{"$(params.foo) && $(params.inexistent)"},
-		}},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "$(params.foo) && $(params.inexistent)"`,
-			Paths:   []string{"steps[0].args[0]"},
+		name: "invalid allowed pattern",
+		param: v1.ParamSpec{
+			Name:           "flags",
+			Type:           v1.ParamTypeArray,
+			AllowedPattern: "--[a-z",
+		},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: --[a-z",
+			Paths:   []string{"params[flags].allowedPattern"},
+			Details: "error parsing regexp: missing closing ]: `[a-z)$`",
 		},
 	}, {
-		name: "object param variable with non-existent properties",
-		Params: []v1.ParamSpec{{
-			Name: "foo",
-			Type: v1.ParamTypeObject,
-		}},
-		Steps: validSteps,
-		expectedError: apis.FieldError{
-			Message: "missing field(s)",
-			Paths:   []string{"foo.properties"},
+		name: "allowed pattern on object param",
+		param: v1.ParamSpec{
+			Name:           "obj",
+			Type:           v1.ParamTypeObject,
+			Properties:     map[string]v1.PropertySpec{"key": {Type: v1.ParamTypeString}},
+			AllowedPattern: "[a-z]+",
+		},
+		expectedError: &apis.FieldError{
+			Message: "allowedPattern cannot be set with object type param",
+			Paths:   []string{"params[obj].allowedPattern"},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := v1.ValidateUsageOfDeclaredParameters(t.Context(), tt.Steps, tt.Params)
-			if err == nil {
-				t.Fatalf("Expected an error, got nothing")
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+				}},
 			}
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
 				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestGetArrayIndexParamRefs(t *testing.T) {
-	stepsReferences := []string{}
-	for i := 10; i <= 26; i++ {
-		stepsReferences = append(stepsReferences, fmt.Sprintf("$(params.array-params[%d])", i))
-	}
-	volumesReferences := []string{}
-	for i := 10; i <= 22; i++ {
-		volumesReferences = append(volumesReferences, fmt.Sprintf("$(params.array-params[%d])", i))
+func TestTaskSpecValidate_ArrayReferenceIsolation(t *testing.T) {
+	params := []v1.ParamSpec{{
+		Name: "arr",
+		Type: v1.ParamTypeArray,
+	}}
+	tests := []struct {
+		name          string
+		command       []string
+		args          []string
+		expectedError *apis.FieldError
+	}{{
+		name:    "standalone star reference",
+		command: []string{"$(params.arr[*])"},
+		args:    []string{"--flag", "$(params.arr[*])"},
+	}, {
+		name: "star reference followed by text in args",
+		args: []string{"$(params.arr[*])prefix"},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "$(params.arr[*])prefix"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}, {
+		name:    "star reference followed by text in command",
+		command: []string{"echo", "$(params.arr[*])prefix"},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "$(params.arr[*])prefix"`,
+			Paths:   []string{"steps[0].command[1]"},
+		},
+	}, {
+		name: "star reference followed by another reference",
+		args: []string{"$(params.arr[*])$(params.arr[*])"},
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "$(params.arr[*])$(params.arr[*])"`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []v1.Step{{
+				Name:    "mystep",
+				Image:   "myimage",
+				Command: tt.command,
+				Args:    tt.args,
+			}}
+			err := v1.ValidateParameterVariables(t.Context(), steps, params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
+}
 
-	tcs := []struct {
-		name     string
-		taskspec *v1.TaskSpec
-		want     sets.String
-	}{
-		{
-			name: "steps reference",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Steps: []v1.Step{{
-					Name:    "$(params.array-params[10])",
-					Image:   "$(params.array-params[11])",
-					Command: []string{"$(params.array-params[12])"},
-					Args:    []string{"$(params.array-params[13])"},
-					Script:  "echo $(params.array-params[14])",
-					Env: []corev1.EnvVar{{
-						Value: "$(params.array-params[15])",
-						ValueFrom: &corev1.EnvVarSource{
-							SecretKeyRef: &corev1.SecretKeySelector{
-								Key: "$(params.array-params[16])",
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[17])",
-								},
-							},
-							ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
-								Key: "$(params.array-params[18])",
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[19])",
-								},
-							},
-						},
-					}},
-					EnvFrom: []corev1.EnvFromSource{{
-						Prefix: "$(params.array-params[20])",
-						ConfigMapRef: &corev1.ConfigMapEnvSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: "$(params.array-params[21])",
-							},
-						},
-						SecretRef: &corev1.SecretEnvSource{
-							LocalObjectReference: corev1.LocalObjectReference{
-								Name: "$(params.array-params[22])",
-							},
-						},
-					}},
-					WorkingDir: "$(params.array-params[23])",
-					VolumeMounts: []corev1.VolumeMount{{
-						Name:      "$(params.array-params[24])",
-						MountPath: "$(params.array-params[25])",
-						SubPath:   "$(params.array-params[26])",
-					}},
-				}},
-				StepTemplate: &v1.StepTemplate{
-					Image: "$(params.array-params[27])",
-				},
-			},
-			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
-				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
-				"$(params.array-params[21])", "$(params.array-params[22])", "$(params.array-params[23])", "$(params.array-params[24])", "$(params.array-params[25])", "$(params.array-params[26])", "$(params.array-params[27])"),
-		}, {
-			name: "stepTemplate reference",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				StepTemplate: &v1.StepTemplate{
-					Image: "$(params.array-params[3])",
-				},
-			},
-			want: sets.NewString("$(params.array-params[3])"),
-		}, {
-			name: "volumes references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Volumes: []corev1.Volume{
-					{
-						Name: "$(params.array-params[10])",
-						VolumeSource: corev1.VolumeSource{
-							ConfigMap: &corev1.ConfigMapVolumeSource{
-								LocalObjectReference: corev1.LocalObjectReference{
-									Name: "$(params.array-params[11])",
-								},
-								Items: []corev1.KeyToPath{
-									{
-										Key:  "$(params.array-params[12])",
-										Path: "$(params.array-params[13])",
-									},
-								},
-							},
-							Secret: &corev1.SecretVolumeSource{
-								SecretName: "$(params.array-params[14])",
-								Items: []corev1.KeyToPath{{
-									Key:  "$(params.array-params[15])",
-									Path: "$(params.array-params[16])",
-								}},
-							},
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "$(params.array-params[17])",
-							},
-							Projected: &corev1.ProjectedVolumeSource{
-								Sources: []corev1.VolumeProjection{{
-									ConfigMap: &corev1.ConfigMapProjection{
-										LocalObjectReference: corev1.LocalObjectReference{
-											Name: "$(params.array-params[18])",
-										},
-									},
-									Secret: &corev1.SecretProjection{
-										LocalObjectReference: corev1.LocalObjectReference{
-											Name: "$(params.array-params[19])",
-										},
-									},
-									ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
-										Audience: "$(params.array-params[20])",
-									},
-								}},
-							},
-							CSI: &corev1.CSIVolumeSource{
-								NodePublishSecretRef: &corev1.LocalObjectReference{
-									Name: "$(params.array-params[21])",
-								},
-								VolumeAttributes: map[string]string{"key": "$(params.array-params[22])"},
-							},
-						},
-					},
-				},
+func TestTaskSpecValidate_StepTemplateResultReferences(t *testing.T) {
+	tests := []struct {
+		name          string
+		ts            *v1.TaskSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "step result consumed through the stepTemplate",
+		ts: &v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Env: []corev1.EnvVar{{Name: "OUT", Value: "$(steps.producer.results.out)"}},
 			},
-			want: sets.NewString("$(params.array-params[10])", "$(params.array-params[11])", "$(params.array-params[12])", "$(params.array-params[13])", "$(params.array-params[14])",
-				"$(params.array-params[15])", "$(params.array-params[16])", "$(params.array-params[17])", "$(params.array-params[18])", "$(params.array-params[19])", "$(params.array-params[20])",
-				"$(params.array-params[21])", "$(params.array-params[22])"),
-		}, {
-			name: "workspaces references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
-				}},
-				Workspaces: []v1.WorkspaceDeclaration{{
-					MountPath: "$(params.array-params[3])",
-				}},
+			Steps: []v1.Step{{
+				Name:    "producer",
+				Image:   "myimage",
+				Script:  "date | tee $(step.results.out.path)",
+				OnError: v1.Continue,
+				Results: []v1.StepResult{{Name: "out"}},
+			}, {
+				Name:  "consumer",
+				Image: "myimage",
+			}},
+		},
+	}, {
+		name: "task result written through the stepTemplate",
+		ts: &v1.TaskSpec{
+			StepTemplate: &v1.StepTemplate{
+				Args: []string{"$(results.out.path)"},
 			},
-			want: sets.NewString("$(params.array-params[3])"),
+			Steps: []v1.Step{{
+				Name:  "writer",
+				Image: "myimage",
+			}},
+			Results: []v1.TaskResult{{Name: "out"}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ResultsDirNotShadowed(t *testing.T) {
+	tests := []struct {
+		name          string
+		ts            *v1.TaskSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "volumeMount next to the results directory",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:         "producer",
+				Image:        "myimage",
+				Script:       "date | tee $(step.results.out.path)",
+				Results:      []v1.StepResult{{Name: "out"}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}, {
+		name: "volumeMount at the root shadows the results directory",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:         "producer",
+				Image:        "myimage",
+				Script:       "date | tee $(step.results.out.path)",
+				Results:      []v1.StepResult{{Name: "out"}},
+				VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+		expectedError: &apis.FieldError{
+			Message: `volumeMount "data" mounted at "/" shadows the results directory "/tekton/results"`,
+			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
+		},
+	}, {
+		name: "volumeMount at the root of a step without results",
+		ts: &v1.TaskSpec{
+			Steps: []v1.Step{{
+				Name:         "step",
+				Image:        "myimage",
+				VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskValidate_DeduplicatedErrors(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{Steps: []v1.Step{{
+			Name:   "mystep",
+			Image:  "$(params.foo)",
+			Args:   []string{"--flag", "$(params.foo)"},
+			Script: "echo $(params.foo)",
 		}, {
-			name: "sidecar references",
-			taskspec: &v1.TaskSpec{
-				Params: []v1.ParamSpec{{
-					Name:    "array-params",
-					Default: v1.NewStructuredValues("bar", "foo"),
+			Name:       "otherstep",
+			Image:      "myimage",
+			WorkingDir: "/workspace/$(params.foo)",
+			Command:    []string{"echo $(params.bar)"},
+		}}},
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedError string
+	}{{
+		name: "full detail by default",
+		ctx:  t.Context(),
+		expectedError: `non-existent variable in "$(params.foo)": spec.steps[0].args[1], spec.steps[0].image
+non-existent variable in "/workspace/$(params.foo)": spec.steps[1].workingDir
+non-existent variable in "echo $(params.bar)": spec.steps[1].command[0]
+non-existent variable in "echo $(params.foo)": spec.steps[0].script`,
+	}, {
+		name: "deduplicated",
+		ctx:  v1.WithDeduplicatedErrors(t.Context()),
+		expectedError: "non-existent variable `bar` in \"echo $(params.bar)\": spec.steps[1].command[0]\n" +
+			"non-existent variable `foo`: spec.steps[0].args[1], spec.steps[0].image, spec.steps[0].script, spec.steps[1].workingDir",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := task.Validate(tt.ctx)
+			if d := cmp.Diff(tt.expectedError, err.Error()); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateTasks(t *testing.T) {
+	valid := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+		Spec:       v1.TaskSpec{Steps: validSteps},
+	}
+	noSteps := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "no-steps"},
+	}
+	undeclaredParam := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "undeclared-param"},
+		Spec: v1.TaskSpec{Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
+			Args:  []string{"$(params.inexistent)"},
+		}}},
+	}
+	got := v1.ValidateTasks(t.Context(), []*v1.Task{valid, noSteps, nil, undeclaredParam})
+
+	want := map[string]string{
+		"no-steps":         noSteps.Validate(t.Context()).Error(),
+		"undeclared-param": undeclaredParam.Validate(t.Context()).Error(),
+	}
+	gotErrors := map[string]string{}
+	for name, err := range got {
+		gotErrors[name] = err.Error()
+	}
+	if d := cmp.Diff(want, gotErrors); d != "" {
+		t.Errorf("ValidateTasks() errors diff %s", diff.PrintWantGot(d))
+	}
+	if _, ok := got["valid"]; ok {
+		t.Errorf("ValidateTasks() returned errors for the valid Task: %v", got["valid"])
+	}
+}
+
+func TestTaskSpecValidate_ResultsHaveSteps(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name:  "results with steps",
+		steps: []v1.Step{{Name: "producer", Image: "myimage", Script: "date | tee $(results.out.path)"}},
+	}, {
+		name:          "results without steps",
+		expectedError: apis.ErrMissingField("steps").Also(apis.ErrGeneric("results are declared but there are no steps to produce them", "results")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:   tt.steps,
+				Results: []v1.TaskResult{{Name: "out", Type: v1.ResultsTypeString}},
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_WorkingDirOverride(t *testing.T) {
+	tests := []struct {
+		name            string
+		stepTemplate    *v1.StepTemplate
+		workingDir      string
+		expectedWarning *apis.FieldError
+	}{{
+		name:         "workingDir only in the stepTemplate",
+		stepTemplate: &v1.StepTemplate{WorkingDir: "/workspace/src"},
+	}, {
+		name:       "workingDir only in the step",
+		workingDir: "/workspace/src",
+	}, {
+		name:         "same workingDir in the stepTemplate and the step",
+		stepTemplate: &v1.StepTemplate{WorkingDir: "/workspace/src"},
+		workingDir:   "/workspace/src",
+	}, {
+		name:            "workingDir in the stepTemplate and the step",
+		stepTemplate:    &v1.StepTemplate{WorkingDir: "/workspace/src"},
+		workingDir:      "/workspace/docs",
+		expectedWarning: apis.ErrGeneric(`workingDir "/workspace/docs" overrides the workingDir "/workspace/src" of the stepTemplate`, "steps[1].workingDir").At(apis.WarningLevel),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				StepTemplate: tt.stepTemplate,
+				Steps: []v1.Step{{
+					Name:  "build",
+					Image: "myimage",
+				}, {
+					Name:       "docs",
+					Image:      "myimage",
+					WorkingDir: tt.workingDir,
 				}},
-				Sidecars: []v1.Sidecar{
-					{
-						Script: "$(params.array-params[3])",
-					},
-				},
-			},
-			want: sets.NewString("$(params.array-params[3])"),
-		},
+			}
+			err := ts.Validate(t.Context())
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("TaskSpec.Validate() returned unexpected errors: %v", err)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
-	for _, tc := range tcs {
-		t.Run(tc.name, func(t *testing.T) {
-			got := tc.taskspec.GetIndexingReferencesToArrayParams()
-			if d := cmp.Diff(tc.want, got); d != "" {
-				t.Errorf("GetIndexingReferencesToArrayParams diff %s", diff.PrintWantGot(d))
+}
+
+func TestTaskSpecValidate_DefaultValuesOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "array default keeps the order of the spec",
+		param: v1.ParamSpec{
+			Name:    "foo",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("$(params.b)", "$(params.a)"),
+		},
+		expectedError: apis.ErrGeneric(`default value "$(params.b)" references another param, which will not be resolved`, "params.foo.default").At(apis.WarningLevel),
+	}, {
+		name: "object default is sorted by key",
+		param: v1.ParamSpec{
+			Name:       "foo",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"z": {Type: v1.ParamTypeString}, "a": {Type: v1.ParamTypeString}},
+			Default: &v1.ParamValue{Type: v1.ParamTypeObject, ObjectVal: map[string]string{
+				"z": "$(params.a)",
+				"a": "$(params.b)",
+			}},
+		},
+		expectedError: apis.ErrGeneric(`default value "$(params.b)" references another param, which will not be resolved`, "params.foo.default").At(apis.WarningLevel),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param, {Name: "a"}, {Name: "b"}},
+				Steps:  validSteps,
+			}
+			// The message must not depend on the iteration order of the default values.
+			for range 20 {
+				err := ts.Validate(t.Context())
+				if d := cmp.Diff(tt.expectedError.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+					t.Fatalf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+				}
 			}
 		})
 	}
 }
 
-func TestParamEnum_Success(t *testing.T) {
-	tcs := []struct {
-		name      string
-		params    v1.ParamSpecs
-		configMap map[string]string
+func TestTaskSpecValidate_DefaultParamCycles(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "valid param enum - success",
+		name: "acyclic references",
 		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
+			Name:    "a",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("$(params.b)"),
 		}, {
-			Name: "param2",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
+			Name:    "b",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("$(params.c)"),
+		}, {
+			Name:    "c",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("value"),
 		}},
 	}, {
-		name: "valid empty param enum - success",
+		name: "direct self-reference",
 		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeString,
+			Name:    "a",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("prefix-$(params.a)"),
+		}},
+		expectedError: &apis.FieldError{
+			Message: `default values of params form a cycle: "a" -> "a"`,
+			Paths:   []string{"params.a.default"},
+		},
+	}, {
+		name: "two-param cycle",
+		params: []v1.ParamSpec{{
+			Name:    "a",
+			Type:    v1.ParamTypeArray,
+			Default: v1.NewStructuredValues("$(params.b)", "value"),
 		}, {
-			Name: "param2",
-			Type: v1.ParamTypeString,
+			Name:    "b",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("$(params.a[*])"),
 		}},
+		expectedError: &apis.FieldError{
+			Message: `default values of params form a cycle: "a" -> "b" -> "a"`,
+			Paths:   []string{"params.a.default"},
+		},
 	}}
-
-	for _, tc := range tcs {
-		cfg := map[string]string{"enable-param-enum": "true"}
-		ctx := cfgtesting.SetFeatureFlags(t.Context(), t, cfg)
-
-		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
-		if err != nil {
-			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: tt.params,
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+				}},
+			}
+			err := ts.Validate(t.Context()).Filter(apis.ErrorLevel)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
 
-func TestParamEnum_Failure(t *testing.T) {
-	tcs := []struct {
-		name        string
-		params      v1.ParamSpecs
-		configMap   map[string]string
-		expectedErr error
+func TestTaskSpecValidate_OnErrorParamEnum(t *testing.T) {
+	tests := []struct {
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "param default val not in enum list - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
+		name: "enum of valid onError values",
+		param: v1.ParamSpec{
+			Name: "mode",
 			Type: v1.ParamTypeString,
-			Default: &v1.ParamValue{
-				Type:      v1.ParamTypeString,
-				StringVal: "v4",
-			},
-			Enum: []string{"v1", "v2"},
-		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
+			Enum: []string{"continue", "stopAndFail"},
 		},
-		expectedErr: errors.New("param default value v4 not in the enum list: params[param1]"),
 	}, {
-		name: "param enum with array type - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeArray,
-			Enum: []string{"v1", "v2"},
+		name: "enum containing an invalid onError value",
+		param: v1.ParamSpec{
+			Name: "mode",
+			Type: v1.ParamTypeString,
+			Enum: []string{"continue", "ignore"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `param "mode" allows the value "ignore", which is not a valid onError value`,
+			Paths:   []string{"steps[0].onError"},
+			Details: "Task step onError must be either \"continue\" or \"stopAndFail\"",
+		},
+	}, {
+		name: "param without enum is skipped",
+		param: v1.ParamSpec{
+			Name: "mode",
+			Type: v1.ParamTypeString,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(t.Context(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{EnableParamEnum: true},
+			})
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param},
+				Steps: []v1.Step{{
+					Name:    "mystep",
+					Image:   "myimage",
+					OnError: "$(params.mode)",
+				}},
+			}
+			err := ts.Validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_DefaultMaxDescriptionLength(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:  "mystep",
+			Image: "myimage",
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
+		Results: []v1.TaskResult{{
+			Name:        "result",
+			Description: strings.Repeat("a", 64*1024),
+		}},
+	}
+	if err := ts.Validate(t.Context()).Filter(apis.ErrorLevel); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v, want no error since descriptions are not limited by default", err)
+	}
+}
+
+func TestTaskSpecValidate_DescriptionVariableReferences(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     string
+		expectedWarning *apis.FieldError
+	}{{
+		name:        "plain description",
+		description: "the revision to check out, e.g. main",
+	}, {
+		name:        "escaped reference",
+		description: "the revision, referenced as $$(params.revision)",
+	}, {
+		name:            "reference",
+		description:     "the revision, defaults to $(params.branch)",
+		expectedWarning: apis.ErrGeneric("description references $(params.branch), variables are not substituted in descriptions", "params.revision.description", "results[0].description").At(apis.WarningLevel),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name:        "revision",
+					Type:        v1.ParamTypeString,
+					Description: tt.description,
+				}},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					Args:  []string{"$(params.revision)", "$(results.commit.path)"},
+				}},
+				Results: []v1.TaskResult{{
+					Name:        "commit",
+					Description: tt.description,
+				}},
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_ArrayIndexes(t *testing.T) {
+	tests := []struct {
+		name          string
+		arg           string
+		expectedError *apis.FieldError
+	}{{
+		name: "non-negative index",
+		arg:  "$(params.arr[0])",
+	}, {
+		name: "negative index",
+		arg:  "$(params.arr[-1])",
+		expectedError: &apis.FieldError{
+			Message: `invalid index "-1" in "$(params.arr[-1])", array elements must be referenced with a non-negative integer`,
+			Paths:   []string{"steps[0].args[0]"},
 		},
-		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
 	}, {
-		name: "param enum with object type - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeObject,
-			Enum: []string{"v1", "v2"},
+		name: "non-integer index",
+		arg:  "--flag=$(params.arr[abc])",
+		expectedError: &apis.FieldError{
+			Message: `invalid index "abc" in "$(params.arr[abc])", array elements must be referenced with a non-negative integer`,
+			Paths:   []string{"steps[0].args[0]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{{Name: "arr", Type: v1.ParamTypeArray}},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+					Args:  []string{tt.arg},
+				}},
+			}
+			err := ts.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskSpecValidate_WorkspaceOptionality(t *testing.T) {
+	tests := []struct {
+		name            string
+		optional        bool
+		steps           []v1.Step
+		expectedWarning *apis.FieldError
+	}{{
+		name:     "optional workspace checked before it is used",
+		optional: true,
+		steps: []v1.Step{{
+			Name:   "guarded",
+			Image:  "myimage",
+			Script: `if [ "$(workspaces.ws.bound)" = "true" ]; then ls $(workspaces.ws.path); fi`,
+		}, {
+			Name:  "when-guarded",
+			Image: "myimage",
+			Args:  []string{"$(workspaces.ws.path)"},
+			When:  v1.StepWhenExpressions{{Input: "$(workspaces.ws.bound)", Operator: selection.In, Values: []string{"true"}}},
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
-		},
-		expectedErr: errors.New("enum can only be set with string type param: params[param1]"),
 	}, {
-		name: "param enum with duplicate values - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v1", "v2"},
+		name: "required workspace used unconditionally",
+		steps: []v1.Step{{
+			Name:   "unguarded",
+			Image:  "myimage",
+			Script: "ls $(workspaces.ws.path)",
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "true",
+	}, {
+		name:     "optional workspace used without checking it is bound",
+		optional: true,
+		steps: []v1.Step{{
+			Name:   "guarded",
+			Image:  "myimage",
+			Script: `if [ "$(workspaces.ws.bound)" = "true" ]; then ls $(workspaces.ws.path); fi`,
+		}, {
+			Name:   "unguarded",
+			Image:  "myimage",
+			Script: "ls $(workspaces.ws.path)",
+		}},
+		expectedWarning: &apis.FieldError{
+			Message: `workspace "ws" is declared optional, but it is used without checking $(workspaces.ws.bound)`,
+			Paths:   []string{"steps[1]"},
 		},
-		expectedErr: errors.New("parameter enum value v1 appears more than once: params[param1]"),
 	}, {
-		name: "param enum with feature flag disabled - failure",
-		params: []v1.ParamSpec{{
-			Name: "param1",
-			Type: v1.ParamTypeString,
-			Enum: []string{"v1", "v2"},
+		name: "required workspace checked as if it were optional",
+		steps: []v1.Step{{
+			Name:   "guarded",
+			Image:  "myimage",
+			Script: `if [ "$(workspaces.ws.bound)" = "true" ]; then ls $(workspaces.ws.path); fi`,
 		}},
-		configMap: map[string]string{
-			"enable-param-enum": "false",
+		expectedWarning: &apis.FieldError{
+			Message: `workspace "ws" is checked with $(workspaces.ws.bound), but it is not declared optional so it is always bound`,
+			Paths:   []string{"steps[0]"},
 		},
-		expectedErr: errors.New("feature flag `enable-param-enum` should be set to true to use Enum: params[param1]"),
 	}}
-
-	for _, tc := range tcs {
-		ctx := cfgtesting.SetFeatureFlags(t.Context(), t, tc.configMap)
-
-		err := v1.ValidateParameterVariables(ctx, []v1.Step{{Image: "foo"}}, tc.params)
-
-		if err == nil {
-			t.Errorf("No error expected from ValidateParameterVariables() but got = %v", err)
-		} else if d := cmp.Diff(tc.expectedErr.Error(), err.Error()); d != "" {
-			t.Errorf("Rerturned error from ValidateParameterVariables() does not match with the expected error: %s", diff.PrintWantGot(d))
-		}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{Name: "ws", Optional: tt.optional}},
+				Steps:      tt.steps,
+			}
+			err := ts.Validate(t.Context())
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("TaskSpec.Validate() = %v, want no errors", err)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
 
-func TestTaskSpecValidate_StepResults(t *testing.T) {
-	type fields struct {
-		Image   string
-		Args    []string
-		Script  string
-		Results []v1.StepResult
+func TestTaskSpecValidate_VolumesNamedAfterWorkspaces(t *testing.T) {
+	tests := []struct {
+		name            string
+		volumeName      string
+		expectedWarning *apis.FieldError
+	}{{
+		name:       "volume and workspace with distinct names",
+		volumeName: "cache",
+	}, {
+		name:       "volume named after a workspace mounted at a different path",
+		volumeName: "source",
+		expectedWarning: &apis.FieldError{
+			Message: `volume "source" has the same name as a workspace but is mounted at "/cache" instead of the workspace mount path "/workspace/source", they do not refer to the same source`,
+			Paths:   []string{"steps[0].volumeMounts[0].mountPath"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Workspaces: []v1.WorkspaceDeclaration{{Name: "source"}},
+				Volumes: []corev1.Volume{{
+					Name:         tt.volumeName,
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+				Steps: []v1.Step{{
+					Name:         "mystep",
+					Image:        "myimage",
+					Script:       "ls $(workspaces.source.path)",
+					VolumeMounts: []corev1.VolumeMount{{Name: tt.volumeName, MountPath: "/cache"}},
+				}},
+			}
+			err := ts.Validate(t.Context())
+			if err.Filter(apis.ErrorLevel) != nil {
+				t.Fatalf("TaskSpec.Validate() = %v, want no errors", err)
+			}
+			if d := cmp.Diff(tt.expectedWarning.Error(), err.Filter(apis.WarningLevel).Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() warnings diff %s", diff.PrintWantGot(d))
+			}
+		})
 	}
+}
+
+func TestTaskValidate_ObjectParamDefaultWithoutProperties(t *testing.T) {
 	tests := []struct {
-		name   string
-		fields fields
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "valid result",
-		fields: fields{
-			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Description: "my great result",
-			}},
+		name: "object default without properties",
+		param: v1.ParamSpec{
+			Name:    "gitrepo",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewObject(map[string]string{"url": "https://github.com/tektoncd/pipeline"}),
 		},
-	}, {
-		name: "valid result type string",
-		fields: fields{
-			Image:  "my-image",
-			Args:   []string{"arg"},
-			Script: "echo $(step.results.MY-RESULT.path)",
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        "string",
-				Description: "my great result",
-			}},
+		expectedError: &apis.FieldError{
+			Message: `object param "gitrepo" declares a default value but no properties, so the keys of the default value cannot be validated`,
+			Paths:   []string{"spec.params.gitrepo.properties"},
+			Details: "declare the keys of the object in properties",
 		},
 	}, {
-		name: "valid result type array",
-		fields: fields{
-			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        v1.ResultsTypeArray,
-				Description: "my great result",
-			}},
+		name: "JSON string default without properties",
+		param: v1.ParamSpec{
+			Name:    "gitrepo",
+			Type:    v1.ParamTypeObject,
+			Default: v1.NewStructuredValues(`{"url": "https://github.com/tektoncd/pipeline"}`),
+		},
+		expectedError: &apis.FieldError{
+			Message: `object param "gitrepo" declares a default value but no properties, so the keys of the default value cannot be validated`,
+			Paths:   []string{"spec.params.gitrepo.properties"},
+			Details: "declare the keys of the object in properties",
 		},
 	}, {
-		name: "valid result type object",
-		fields: fields{
-			Image: "my-image",
-			Args:  []string{"arg"},
-			Results: []v1.StepResult{{
-				Name:        "MY-RESULT",
-				Type:        v1.ResultsTypeObject,
-				Description: "my great result",
-				Properties: map[string]v1.PropertySpec{
-					"url":    {Type: "string"},
-					"commit": {Type: "string"},
-				},
-			}},
+		name: "no default and no properties",
+		param: v1.ParamSpec{
+			Name: "gitrepo",
+			Type: v1.ParamTypeObject,
 		},
+		expectedError: apis.ErrMissingField("spec.gitrepo.properties"),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ts := &v1.TaskSpec{
-				Steps: []v1.Step{{
-					Image:   tt.fields.Image,
-					Args:    tt.fields.Args,
-					Script:  tt.fields.Script,
-					Results: tt.fields.Results,
-				}},
+			task := &v1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1.TaskSpec{
+					Params: []v1.ParamSpec{tt.param},
+					Steps: []v1.Step{{
+						Name:  "mystep",
+						Image: "myimage",
+					}},
+				},
 			}
-			ctx := config.ToContext(t.Context(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{},
-			})
-			ts.SetDefaults(ctx)
-			if err := ts.Validate(ctx); err != nil {
-				t.Errorf("TaskSpec.Validate() = %v", err)
+			err := task.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskSpecValidate_StepResults_Error(t *testing.T) {
-	type fields struct {
-		Image   string
-		Script  string
-		Results []v1.StepResult
-	}
+func TestTaskSpecValidate_ParamItemCounts(t *testing.T) {
 	tests := []struct {
-		name            string
-		fields          fields
-		expectedError   apis.FieldError
-		isCreate        bool
-		isUpdate        bool
-		baselineTaskRun *v1.TaskRun
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "step script refers to nonexistent result",
-		fields: fields{
-			Image: "my-image",
-			Script: `
-			#!/usr/bin/env bash
-			date | tee $(results.non-exist.path)`,
-			Results: []v1.StepResult{{Name: "a-result"}},
+		name: "default within the item counts",
+		param: v1.ParamSpec{
+			Name:     "flags",
+			Type:     v1.ParamTypeArray,
+			MinItems: 1,
+			MaxItems: 2,
+			Default:  v1.NewStructuredValues("--verbose", "--dry-run"),
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(results.non-exist.path)"`,
-			Paths:   []string{"steps[0].script"},
+	}, {
+		name: "default with fewer items than the minimum",
+		param: v1.ParamSpec{
+			Name:     "flags",
+			Type:     v1.ParamTypeArray,
+			MinItems: 1,
+			Default:  &v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{}},
+		},
+		expectedError: &apis.FieldError{
+			Message: "param default value has 0 items, fewer than the minimum of 1",
+			Paths:   []string{"params[flags].default"},
 		},
 	}, {
-		name: "step script refers to nonexistent stepresult",
-		fields: fields{
-			Image: "my-image",
-			Script: `
-			#!/usr/bin/env bash
-			date | tee $(step.results.non-exist.path)`,
-			Results: []v1.StepResult{{Name: "a-result"}},
+		name: "default with more items than the maximum",
+		param: v1.ParamSpec{
+			Name:     "flags",
+			Type:     v1.ParamTypeArray,
+			MaxItems: 2,
+			Default:  v1.NewStructuredValues("--verbose", "--dry-run", "--quiet"),
 		},
-		expectedError: apis.FieldError{
-			Message: `non-existent variable in "\n\t\t\t#!/usr/bin/env bash\n\t\t\tdate | tee $(step.results.non-exist.path)"`,
-			Paths:   []string{"steps[0].script"},
+		expectedError: &apis.FieldError{
+			Message: "param default value has 3 items, more than the maximum of 2",
+			Paths:   []string{"params[flags].default"},
+		},
+	}, {
+		name: "minItems greater than maxItems",
+		param: v1.ParamSpec{
+			Name:     "flags",
+			Type:     v1.ParamTypeArray,
+			MinItems: 3,
+			MaxItems: 2,
+		},
+		expectedError: &apis.FieldError{
+			Message: "minItems 3 is greater than maxItems 2",
+			Paths:   []string{"params[flags].maxItems", "params[flags].minItems"},
+		},
+	}, {
+		name: "item counts on a string param",
+		param: v1.ParamSpec{
+			Name:     "flag",
+			Type:     v1.ParamTypeString,
+			MinItems: 1,
+		},
+		expectedError: &apis.FieldError{
+			Message: "minItems and maxItems can only be set with array type param",
+			Paths:   []string{"params[flag]"},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param},
 				Steps: []v1.Step{{
-					Image:   tt.fields.Image,
-					Script:  tt.fields.Script,
-					Results: tt.fields.Results,
+					Name:  "mystep",
+					Image: "myimage",
 				}},
 			}
-			ctx := t.Context()
-			if tt.isCreate {
-				ctx = apis.WithinCreate(ctx)
-			}
-			if tt.isUpdate {
-				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
-			}
-			ts.SetDefaults(ctx)
-			err := ts.Validate(ctx)
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
 }
 
-func TestTaskSpecValidate_StepWhen_Error(t *testing.T) {
+func TestTaskSpecValidate_ParamFormat(t *testing.T) {
 	tests := []struct {
-		name            string
-		ts              *v1.TaskSpec
-		isCreate        bool
-		Results         []v1.StepResult
-		isUpdate        bool
-		baselineTaskRun *v1.TaskRun
-		expectedError   apis.FieldError
-		EnableCEL       bool
+		name          string
+		param         v1.ParamSpec
+		expectedError *apis.FieldError
 	}{{
-		name: "cel not allowed if EnableCELInWhenExpression is false",
-		ts: &v1.TaskSpec{Steps: []v1.Step{{
-			Image: "my-image",
-			When:  v1.StepWhenExpressions{{CEL: "'d'=='d'"}},
-		}}},
-		expectedError: apis.FieldError{
-			Message: `feature flag enable-cel-in-whenexpression should be set to true to use CEL: 'd'=='d' in WhenExpression`,
-			Paths:   []string{"steps[0].when[0]"},
+		name:  "valid uri default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "uri", Default: v1.NewStructuredValues("https://example.com/repo.git")},
+	}, {
+		name:  "invalid uri default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "uri", Default: v1.NewStructuredValues("example.com/repo.git")},
+		expectedError: &apis.FieldError{
+			Message: `param default value "example.com/repo.git" is not a valid uri`,
+			Paths:   []string{"params.p.default"},
 		},
-	},
-	}
+	}, {
+		name:  "valid email default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "email", Default: v1.NewStructuredValues("jane@example.com")},
+	}, {
+		name:  "invalid email default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "email", Default: v1.NewStructuredValues("Jane <jane@example.com>")},
+		expectedError: &apis.FieldError{
+			Message: `param default value "Jane <jane@example.com>" is not a valid email`,
+			Paths:   []string{"params.p.default"},
+		},
+	}, {
+		name:  "valid date-time default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "date-time", Default: v1.NewStructuredValues("2026-10-16T08:00:00Z")},
+	}, {
+		name:  "invalid date-time default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "date-time", Default: v1.NewStructuredValues("2026-10-16")},
+		expectedError: &apis.FieldError{
+			Message: `param default value "2026-10-16" is not a valid date-time`,
+			Paths:   []string{"params.p.default"},
+		},
+	}, {
+		name:  "valid uuid default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "uuid", Default: v1.NewStructuredValues("123e4567-e89b-12d3-a456-426614174000")},
+	}, {
+		name:  "invalid uuid default",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "uuid", Default: v1.NewStructuredValues("urn:uuid:123e4567-e89b-12d3-a456-426614174000")},
+		expectedError: &apis.FieldError{
+			Message: `param default value "urn:uuid:123e4567-e89b-12d3-a456-426614174000" is not a valid uuid`,
+			Paths:   []string{"params.p.default"},
+		},
+	}, {
+		name:  "default referencing a variable is not checked",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "uri", Default: v1.NewStructuredValues("$(context.taskRun.name)")},
+	}, {
+		name:  "unknown format",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeString, Format: "hostname"},
+		expectedError: &apis.FieldError{
+			Message: "invalid value: hostname",
+			Paths:   []string{"params.p.format"},
+			Details: `allowed formats are ["date-time" "email" "uri" "uuid"]`,
+		},
+	}, {
+		name:  "format on an array param",
+		param: v1.ParamSpec{Name: "p", Type: v1.ParamTypeArray, Format: "uri"},
+		expectedError: &apis.FieldError{
+			Message: "format can only be set with string type param",
+			Paths:   []string{"params.p.format"},
+		},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := config.ToContext(t.Context(), &config.Config{
-				FeatureFlags: &config.FeatureFlags{
-					EnableCELInWhenExpression: tt.EnableCEL,
-				},
-			})
-			if tt.isCreate {
-				ctx = apis.WithinCreate(ctx)
+			ts := &v1.TaskSpec{
+				Params: []v1.ParamSpec{tt.param},
+				Steps: []v1.Step{{
+					Name:  "mystep",
+					Image: "myimage",
+				}},
 			}
-			if tt.isUpdate {
-				ctx = apis.WithinUpdate(ctx, tt.baselineTaskRun)
+			err := ts.Validate(cfgtesting.EnableAlphaAPIFields(t.Context()))
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
 			}
-			tt.ts.SetDefaults(ctx)
-			err := tt.ts.Validate(ctx)
-			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
-				t.Errorf("StepActionSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+		})
+	}
+}
+
+// largeTaskSpec returns a TaskSpec with n params, steps, workspaces and results, half of the
+// steps referencing undeclared workspaces and results so that several validators report errors.
+func largeTaskSpec(n int) *v1.TaskSpec {
+	ts := &v1.TaskSpec{}
+	for i := range n {
+		ts.Params = append(ts.Params, v1.ParamSpec{
+			Name: fmt.Sprintf("param-%d", i),
+			Type: v1.ParamTypeString,
+		})
+		ts.Workspaces = append(ts.Workspaces, v1.WorkspaceDeclaration{
+			Name: fmt.Sprintf("workspace-%d", i),
+		})
+		ts.Results = append(ts.Results, v1.TaskResult{
+			Name: fmt.Sprintf("result-%d", i),
+		})
+		ts.Steps = append(ts.Steps, v1.Step{
+			Name:   fmt.Sprintf("step-%d", i),
+			Image:  "myimage",
+			Script: fmt.Sprintf("echo $(params.param-%d) $(workspaces.workspace-%d.path) > $(results.result-%d.path)", i, i, i),
+		})
+		if i%2 == 0 {
+			ts.Steps[i].Args = []string{"$(workspaces.undeclared.path)", "$(context.taskRun.uid)"}
+			ts.Steps[i].Script += " $(results.undeclared.path)"
+		}
+	}
+	return ts
+}
+
+func TestTaskSpecValidate_ConcurrentValidation(t *testing.T) {
+	for _, ts := range []*v1.TaskSpec{largeTaskSpec(1), largeTaskSpec(50), {}} {
+		sequential := config.ToContext(t.Context(), &config.Config{FeatureFlags: config.DefaultFeatureFlags.DeepCopy()})
+		flags := config.DefaultFeatureFlags.DeepCopy()
+		flags.EnableConcurrentValidation = true
+		concurrent := config.ToContext(t.Context(), &config.Config{FeatureFlags: flags})
+
+		want := ts.Validate(sequential)
+		if want == nil {
+			t.Fatal("expected the TaskSpec to be invalid")
+		}
+		got := ts.Validate(concurrent)
+		if d := cmp.Diff(want.Error(), got.Error()); d != "" {
+			t.Errorf("TaskSpec.Validate() concurrent errors diff %s", diff.PrintWantGot(d))
+		}
+		if d := cmp.Diff(want, got, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+			t.Errorf("TaskSpec.Validate() concurrent FieldError diff %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func BenchmarkTaskSpecValidate(b *testing.B) {
+	ts := largeTaskSpec(500)
+	for _, concurrent := range []bool{false, true} {
+		b.Run(fmt.Sprintf("concurrent=%t", concurrent), func(b *testing.B) {
+			flags := config.DefaultFeatureFlags.DeepCopy()
+			flags.EnableConcurrentValidation = concurrent
+			ctx := config.ToContext(b.Context(), &config.Config{FeatureFlags: flags})
+			for b.Loop() {
+				ts.Validate(ctx)
 			}
 		})
 	}
@@ -116,10 +116,14 @@ func TestTaskRun_Invalidate(t *testing.T) {
 				},
 			},
 		},
-		want: &apis.FieldError{
+		want: (&apis.FieldError{
 			Message: `missing field(s)`,
 			Paths:   []string{"spec.task-words.properties"},
-		},
+		}).Also(&apis.FieldError{
+			Message: `object param "task-words" declares a default value but no properties, so the keys of the default value cannot be validated`,
+			Paths:   []string{"spec.taskSpec.params.task-words.properties"},
+			Details: "declare the keys of the object in properties",
+		}),
 		wc: cfgtesting.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"strings"
+
+	"knative.dev/pkg/apis"
+)
+
+// ValidationCode identifies the kind of a ValidationIssue.
+type ValidationCode string
+
+const (
+	// ValidationCodeMissingField is used when a required field is not set.
+	ValidationCodeMissingField ValidationCode = "MissingField"
+	// ValidationCodeDisallowedField is used when a field that must not be set is set.
+	ValidationCodeDisallowedField ValidationCode = "DisallowedField"
+	// ValidationCodeInvalidValue is used when a field holds an invalid value.
+	ValidationCodeInvalidValue ValidationCode = "InvalidValue"
+	// ValidationCodeInvalidKeyName is used when a map key is invalid.
+	ValidationCodeInvalidKeyName ValidationCode = "InvalidKeyName"
+	// ValidationCodeMissingOneOf is used when none of a set of mutually exclusive fields is set.
+	ValidationCodeMissingOneOf ValidationCode = "MissingOneOf"
+	// ValidationCodeMultipleOneOf is used when more than one of a set of mutually exclusive fields is set.
+	ValidationCodeMultipleOneOf ValidationCode = "MultipleOneOf"
+	// ValidationCodeGeneric is used for any other issue.
+	ValidationCodeGeneric ValidationCode = "Generic"
+)
+
+// ValidationIssue is a single error or warning found by validation.
+//
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type ValidationIssue struct {
+	// Path is the path of the field the issue was found on, e.g. "spec.steps[0].image".
+	Path string `json:"path"`
+	// Message describes the issue.
+	Message string `json:"message"`
+	// Details holds additional information about the issue, if any.
+	Details string `json:"details,omitempty"`
+	// Code identifies the kind of the issue.
+	Code ValidationCode `json:"code"`
+}
+
+// ValidationReport holds the errors and warnings found by validation, as a machine friendly
+// alternative to apis.FieldError.
+//
+// +k8s:deepcopy-gen=false
+// +k8s:openapi-gen=false
+type ValidationReport struct {
+	Errors   []ValidationIssue `json:"errors,omitempty"`
+	Warnings []ValidationIssue `json:"warnings,omitempty"`
+}
+
+// newValidationReport maps the diagnostics held in fe to a ValidationReport. A diagnostic reported
+// on several paths results in one ValidationIssue per path.
+func newValidationReport(fe *apis.FieldError) ValidationReport {
+	return ValidationReport{
+		Errors:   validationIssues(fe.Filter(apis.ErrorLevel)),
+		Warnings: validationIssues(fe.Filter(apis.WarningLevel)),
+	}
+}
+
+func validationIssues(fe *apis.FieldError) []ValidationIssue {
+	if fe == nil {
+		return nil
+	}
+	var issues []ValidationIssue
+	for _, e := range fe.WrappedErrors() {
+		for _, p := range e.Paths {
+			issues = append(issues, ValidationIssue{
+				Path:    p,
+				Message: e.Message,
+				Details: e.Details,
+				Code:    validationCode(e.Message),
+			})
+		}
+	}
+	return issues
+}
+
+// validationCode returns the ValidationCode of a diagnostic, based on the messages of the
+// apis.FieldError constructors.
+func validationCode(message string) ValidationCode {
+	switch {
+	case message == "missing field(s)":
+		return ValidationCodeMissingField
+	case message == "must not set the field(s)":
+		return ValidationCodeDisallowedField
+	case strings.HasPrefix(message, "invalid value: "):
+		return ValidationCodeInvalidValue
+	case strings.HasPrefix(message, "invalid key name "):
+		return ValidationCodeInvalidKeyName
+	case message == "expected exactly one, got neither":
+		return ValidationCodeMissingOneOf
+	case message == "expected exactly one, got both":
+		return ValidationCodeMultipleOneOf
+	default:
+		return ValidationCodeGeneric
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskValidateStructured(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{
+				Name: "foo",
+				Type: "invalid",
+			}, {
+				Name:    "bar",
+				Type:    v1.ParamTypeString,
+				Default: v1.NewStructuredValues("$(params.foo)"),
+			}},
+			Steps: []v1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"$(params.baz)"},
+			}, {
+				Name:  "mystep",
+				Image: "myimage",
+			}},
+		},
+	}
+	want := v1.ValidationReport{
+		Errors: []v1.ValidationIssue{{
+			Path:    "spec.steps[1].name",
+			Message: "expected exactly one, got both",
+			Code:    v1.ValidationCodeMultipleOneOf,
+		}, {
+			Path:    "spec.params.foo.type",
+			Message: "invalid value: invalid",
+			Code:    v1.ValidationCodeInvalidValue,
+		}, {
+			Path:    "spec.steps[0].args[0]",
+			Message: `non-existent variable in "$(params.baz)"`,
+			Code:    v1.ValidationCodeGeneric,
+		}},
+		Warnings: []v1.ValidationIssue{{
+			Path:    "spec.params.bar.default",
+			Message: `default value "$(params.foo)" references another param, which will not be resolved`,
+			Code:    v1.ValidationCodeGeneric,
+		}},
+	}
+
+	got := task.ValidateStructured(t.Context())
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Task.ValidateStructured() diff %s", diff.PrintWantGot(d))
+	}
+
+	// The report holds the same diagnostics as the FieldError returned by Validate.
+	err := task.Validate(t.Context())
+	for level, issues := range map[apis.DiagnosticLevel][]v1.ValidationIssue{apis.ErrorLevel: got.Errors, apis.WarningLevel: got.Warnings} {
+		var fromReport *apis.FieldError
+		for _, i := range issues {
+			fromReport = fromReport.Also(&apis.FieldError{Message: i.Message, Paths: []string{i.Path}, Details: i.Details})
+		}
+		if d := cmp.Diff(err.Filter(level).Error(), fromReport.Error()); d != "" {
+			t.Errorf("Task.ValidateStructured() does not match Task.Validate() at level %v %s", level, diff.PrintWantGot(d))
+		}
+	}
+}
@@ -19,10 +19,12 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/google/cel-go/cel"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/internal/resultref"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -38,6 +40,45 @@ func (wes WhenExpressions) validate(ctx context.Context) *apis.FieldError {
 	return wes.validateWhenExpressionsFields(ctx).ViaField("when")
 }
 
+// validateNoDuplicates returns a warning for each WhenExpression that is identical to an earlier one.
+func (wes WhenExpressions) validateNoDuplicates() (errs *apis.FieldError) {
+	for idx, we := range wes {
+		for prev, other := range wes[:idx] {
+			if equality.Semantic.DeepEqual(other, we) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("when expression is identical to when expression %d", prev), "").ViaIndex(idx).At(apis.WarningLevel))
+				break
+			}
+		}
+	}
+	return errs.ViaField("when")
+}
+
+// validateStepResultsRunBefore returns an error for each WhenExpression of a step referencing the results
+// of a step which is not in ran, i.e. the same or a later step, as its results are not available yet when
+// deciding whether the step runs, or referencing a result which the earlier step does not declare.
+func (wes WhenExpressions) validateStepResultsRunBefore(ran map[string]Step) (errs *apis.FieldError) {
+	for idx, we := range wes {
+		expressions, _ := we.GetVarSubstitutionExpressions()
+		for _, expression := range expressions {
+			pr, err := resultref.ParseStepExpression(expression)
+			if err != nil {
+				continue
+			}
+			producer, ok := ran[pr.ResourceName]
+			if !ok {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("when expression references the results of step %q, which does not run before it", pr.ResourceName), "").ViaIndex(idx))
+				break
+			}
+			// The results of a StepAction are declared by the StepAction.
+			if producer.Ref == nil && !slices.ContainsFunc(producer.Results, func(r StepResult) bool { return r.Name == pr.ResultName }) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("when expression references result %q of step %q, which the step does not declare", pr.ResultName, pr.ResourceName), "").ViaIndex(idx))
+				break
+			}
+		}
+	}
+	return errs.ViaField("when")
+}
+
 func (wes WhenExpressions) validateWhenExpressionsFields(ctx context.Context) (errs *apis.FieldError) {
 	for idx, we := range wes {
 		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaIndex(idx))
@@ -51,7 +92,7 @@ func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *api
 			return apis.ErrGeneric(fmt.Sprintf("feature flag %s should be set to true to use CEL: %s in WhenExpression", config.EnableCELInWhenExpression, we.CEL), "")
 		}
 		if we.Input != "" || we.Operator != "" || len(we.Values) != 0 {
-			return apis.ErrGeneric(fmt.Sprintf("cel and input+operator+values cannot be set in one WhenExpression: %v", we))
+			return apis.ErrGeneric("cel and input+operator+values cannot be set in one WhenExpression", "")
 		}
 
 		// We need to compile the CEL expression and check if it is a valid expression
@@ -63,7 +104,7 @@ func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *api
 		env, _ := cel.NewEnv()
 		_, iss := env.Compile(we.CEL)
 		if iss.Err() != nil {
-			return apis.ErrGeneric("invalid cel expression: %s with err: %s", we.CEL, iss.Err().Error())
+			return apis.ErrGeneric(fmt.Sprintf("invalid cel expression: %s with err: %s", we.CEL, iss.Err().Error()), "")
 		}
 		return nil
 	}
@@ -18,11 +18,20 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
 
+// workspaceNameFormatRegex matches the names which can be used to reference a workspace in
+// variables such as $(workspaces.<name>.path).
+var workspaceNameFormatRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 // allVolumeSourceFields is a list of all the volume source field paths that a
 // WorkspaceBinding may include.
 var allVolumeSourceFields = []string{
@@ -83,6 +92,30 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 	return nil
 }
 
+// Validate checks that the WorkspaceDeclaration has a valid name and that its mount path is
+// absolute and not under the paths reserved by Tekton. Checks that involve the other workspaces
+// of a Task, such as name uniqueness, are done when validating the TaskSpec.
+func (w *WorkspaceDeclaration) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if w.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if !workspaceNameFormatRegex.MatchString(w.Name) {
+		errs = errs.Also(apis.ErrInvalidValue(w.Name, "name", "Workspace names must only contain alphanumeric characters, hyphens (-) and underscores (_)"))
+	}
+	// A mount path containing variables can only be checked once they are replaced.
+	if strings.Contains(w.MountPath, "$(") {
+		return errs
+	}
+	if w.MountPath != "" && !filepath.IsAbs(w.MountPath) {
+		errs = errs.Also(apis.ErrInvalidValue(w.MountPath, "mountPath", "Workspace mount paths must be absolute"))
+	}
+	mountPath := filepath.Clean(w.GetMountPath())
+	underHomeDir := mountPath == pipeline.HomeDir || strings.HasPrefix(mountPath, pipeline.HomeDir+"/")
+	if strings.HasPrefix(mountPath, "/tekton/") && !underHomeDir {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("workspace cannot be mounted under /tekton/ (mounted at %q)", mountPath), "mountPath"))
+	}
+	return errs
+}
+
 // numSources returns the total number of volume sources that this WorkspaceBinding
 // has been configured with.
 func (b *WorkspaceBinding) numSources() int {
@@ -20,11 +20,14 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 func TestWorkspaceBindingValidateValid(t *testing.T) {
@@ -191,3 +194,75 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceDeclarationValidateValid(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		declaration *v1.WorkspaceDeclaration
+	}{{
+		name:        "name only",
+		declaration: &v1.WorkspaceDeclaration{Name: "source"},
+	}, {
+		name:        "name with underscores and uppercase letters",
+		declaration: &v1.WorkspaceDeclaration{Name: "task_Workspace"},
+	}, {
+		name:        "absolute mount path",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "/my/source"},
+	}, {
+		name:        "mount path under the tekton home directory",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "/tekton/home/source"},
+	}, {
+		name:        "mount path at the tekton home directory",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "/tekton/home"},
+	}, {
+		name:        "mount path with variables",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "$(params.dir)/source"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.declaration.Validate(t.Context()); err != nil {
+				t.Errorf("didnt expect error for valid declaration but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestWorkspaceDeclarationValidateInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		declaration   *v1.WorkspaceDeclaration
+		expectedError *apis.FieldError
+	}{{
+		name:          "missing name",
+		declaration:   &v1.WorkspaceDeclaration{MountPath: "/my/source"},
+		expectedError: apis.ErrMissingField("name"),
+	}, {
+		name:          "name with dots",
+		declaration:   &v1.WorkspaceDeclaration{Name: "my.source"},
+		expectedError: apis.ErrInvalidValue("my.source", "name", "Workspace names must only contain alphanumeric characters, hyphens (-) and underscores (_)"),
+	}, {
+		name:          "relative mount path",
+		declaration:   &v1.WorkspaceDeclaration{Name: "source", MountPath: "my/source"},
+		expectedError: apis.ErrInvalidValue("my/source", "mountPath", "Workspace mount paths must be absolute"),
+	}, {
+		name:        "mount path under /tekton/",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "/tekton/results/source"},
+		expectedError: &apis.FieldError{
+			Message: `workspace cannot be mounted under /tekton/ (mounted at "/tekton/results/source")`,
+			Paths:   []string{"mountPath"},
+		},
+	}, {
+		name:        "mount path next to the tekton home directory",
+		declaration: &v1.WorkspaceDeclaration{Name: "source", MountPath: "/tekton/homefoo"},
+		expectedError: &apis.FieldError{
+			Message: `workspace cannot be mounted under /tekton/ (mounted at "/tekton/homefoo")`,
+			Paths:   []string{"mountPath"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.declaration.Validate(t.Context())
+			if d := cmp.Diff(tc.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("WorkspaceDeclaration.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -564,6 +564,13 @@ func (s *StepTemplate) ToK8sContainer() *corev1.Container {
 	}
 }
 
+// toStep returns a Step holding the fields of the StepTemplate, so that it can be validated like a Step.
+func (s *StepTemplate) toStep() Step {
+	step := Step{}
+	step.SetContainerFields(*s.ToK8sContainer())
+	return step
+}
+
 // Sidecar has nearly the same data structure as Step but does not have the ability to timeout.
 type Sidecar struct {
 	// Name of the Sidecar specified as a DNS_LABEL.
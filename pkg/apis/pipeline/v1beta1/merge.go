@@ -46,6 +46,8 @@ func MergeStepsWithStepTemplate(template *StepTemplate, steps []Step) ([]Step, e
 		return nil, err
 	}
 
+	// Merge into a copy so that the steps passed in are left untouched.
+	steps = append([]Step(nil), steps...)
 	for i, s := range steps {
 		// If the stepaction has not been fetched yet then do not merge.
 		// Skip over to the next one
@@ -1313,7 +1313,7 @@ func schema_pkg_apis_pipeline_v1beta1_ParamSpec(ref common.ReferenceCallback) co
 					},
 					"type": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Type is the user-specified type of the parameter. The possible types are currently \"string\", \"array\" and \"object\", and \"string\" is the default.",
+							Description: "Type is the user-specified type of the parameter. The possible types are currently \"string\", \"array\", \"object\" and \"integer\", and \"string\" is the default.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1361,6 +1361,34 @@ func schema_pkg_apis_pipeline_v1beta1_ParamSpec(ref common.ReferenceCallback) co
 							},
 						},
 					},
+					"allowedPattern": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AllowedPattern is a regular expression that the default value of the parameter must fully match. For array parameters each element of the default value must match it. AllowedPattern cannot be set for object parameters.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"minItems": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MinItems is the minimum number of elements the default value of an array parameter must have.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxItems": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxItems is the maximum number of elements the default value of an array parameter can have.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"format": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Format is a semantic format of a string parameter, one of \"date-time\", \"email\", \"uri\" or \"uuid\". The default value of the parameter must be valid for the format.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 				Required: []string{"name"},
 			},
@@ -31,6 +31,10 @@ func (p ParamSpec) convertTo(ctx context.Context, sink *v1.ParamSpec) {
 	}
 	sink.Description = p.Description
 	sink.Enum = p.Enum
+	sink.AllowedPattern = p.AllowedPattern
+	sink.MinItems = p.MinItems
+	sink.MaxItems = p.MaxItems
+	sink.Format = p.Format
 	var properties map[string]v1.PropertySpec
 	if p.Properties != nil {
 		properties = make(map[string]v1.PropertySpec)
@@ -56,6 +60,10 @@ func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
 	}
 	p.Description = source.Description
 	p.Enum = source.Enum
+	p.AllowedPattern = source.AllowedPattern
+	p.MinItems = source.MinItems
+	p.MaxItems = source.MaxItems
+	p.Format = source.Format
 	var properties map[string]PropertySpec
 	if source.Properties != nil {
 		properties = make(map[string]PropertySpec)
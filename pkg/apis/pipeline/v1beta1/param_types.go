@@ -20,8 +20,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	corev1 "k8s.io/api/core/v1"
@@ -40,7 +45,7 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string", "array" and "object", and "string" is the default.
+	// are currently "string", "array", "object" and "integer", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
 	// Description is a user-facing description of the parameter that may be
@@ -61,6 +66,21 @@ type ParamSpec struct {
 	// If Enum is not set, no input validation is performed for the param.
 	// +optional
 	Enum []string `json:"enum,omitempty"`
+	// AllowedPattern is a regular expression that the default value of the parameter must fully match.
+	// For array parameters each element of the default value must match it. AllowedPattern cannot be
+	// set for object parameters.
+	// +optional
+	AllowedPattern string `json:"allowedPattern,omitempty"`
+	// MinItems is the minimum number of elements the default value of an array parameter must have.
+	// +optional
+	MinItems int `json:"minItems,omitempty"`
+	// MaxItems is the maximum number of elements the default value of an array parameter can have.
+	// +optional
+	MaxItems int `json:"maxItems,omitempty"`
+	// Format is a semantic format of a string parameter, one of "date-time", "email", "uri" or "uuid".
+	// The default value of the parameter must be valid for the format.
+	// +optional
+	Format string `json:"format,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
@@ -112,7 +132,9 @@ func (ps ParamSpecs) getNames() []string {
 	return names
 }
 
-// sortByType splits the input params into string params, array params, and object params, in that order
+// sortByType splits the input params into string params, array params, and object params, in that order.
+// The scalar params, i.e. the integer params and the params without a type, hold a single value which
+// is referenced like the one of a string param, so they are sorted with the string params.
 func (ps ParamSpecs) sortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 	var stringParams, arrayParams, objectParams ParamSpecs
 	for _, p := range ps {
@@ -121,7 +143,7 @@ func (ps ParamSpecs) sortByType() (ParamSpecs, ParamSpecs, ParamSpecs) {
 			arrayParams = append(arrayParams, p)
 		case ParamTypeObject:
 			objectParams = append(objectParams, p)
-		case ParamTypeString:
+		case ParamTypeString, ParamTypeInteger:
 			fallthrough
 		default:
 			stringParams = append(stringParams, p)
@@ -165,6 +187,122 @@ func (ps ParamSpecs) validateParamEnums(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// validateParamPatterns validates feature flag for AllowedPattern, that it is a valid regular expression
+// which is not set for object params, and that the default values match it
+func (ps ParamSpecs) validateParamPatterns(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.AllowedPattern == "" {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "allowedPattern", config.AlphaAPIFields).ViaField("allowedPattern").ViaKey(p.Name))
+		if p.Type == ParamTypeObject {
+			errs = errs.Also(apis.ErrGeneric("allowedPattern cannot be set with object type param", "allowedPattern").ViaKey(p.Name))
+			continue
+		}
+		re, err := regexp.Compile("^(?:" + p.AllowedPattern + ")$")
+		if err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(p.AllowedPattern, "allowedPattern", err.Error()).ViaKey(p.Name))
+			continue
+		}
+		if p.Default == nil {
+			continue
+		}
+		switch p.Default.Type {
+		case ParamTypeArray:
+			for i, v := range p.Default.ArrayVal {
+				if !matchesPattern(re, v) {
+					errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q does not match the allowed pattern %q", v, p.AllowedPattern), "").ViaFieldIndex("default", i).ViaKey(p.Name))
+				}
+			}
+		case ParamTypeString:
+			if !matchesPattern(re, p.Default.StringVal) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q does not match the allowed pattern %q", p.Default.StringVal, p.AllowedPattern), "default").ViaKey(p.Name))
+			}
+		}
+	}
+	return errs
+}
+
+// validateParamItemCounts validates feature flag for MinItems and MaxItems, that they are only set for
+// array params, are consistent with each other, and that the default values have a number of elements within them
+func (ps ParamSpecs) validateParamItemCounts(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, p := range ps {
+		if p.MinItems == 0 && p.MaxItems == 0 {
+			continue
+		}
+		errs = errs.Also(config.ValidateEnabledAPIFields(ctx, "minItems and maxItems", config.AlphaAPIFields).ViaKey(p.Name))
+		if p.Type != ParamTypeArray {
+			errs = errs.Also(apis.ErrGeneric("minItems and maxItems can only be set with array type param", "").ViaKey(p.Name))
+			continue
+		}
+		if p.MinItems < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(p.MinItems, "minItems").ViaKey(p.Name))
+		}
+		if p.MaxItems < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(p.MaxItems, "maxItems").ViaKey(p.Name))
+		}
+		if p.MaxItems > 0 && p.MinItems > p.MaxItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("minItems %d is greater than maxItems %d", p.MinItems, p.MaxItems), "minItems", "maxItems").ViaKey(p.Name))
+			continue
+		}
+		if p.Default == nil || p.Default.Type != ParamTypeArray {
+			continue
+		}
+		if n := len(p.Default.ArrayVal); n < p.MinItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value has %d items, fewer than the minimum of %d", n, p.MinItems), "default").ViaKey(p.Name))
+		} else if p.MaxItems > 0 && n > p.MaxItems {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value has %d items, more than the maximum of %d", n, p.MaxItems), "default").ViaKey(p.Name))
+		}
+	}
+	return errs
+}
+
+// paramFormats are the formats a string param can declare, with the function checking that a value is valid for it.
+var paramFormats = map[string]func(string) bool{
+	"date-time": func(v string) bool {
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	},
+	"email": func(v string) bool {
+		addr, err := mail.ParseAddress(v)
+		return err == nil && addr.Address == v
+	},
+	"uri": func(v string) bool {
+		u, err := url.Parse(v)
+		return err == nil && u.Scheme != ""
+	},
+	"uuid": func(v string) bool {
+		// uuid.Parse also accepts the URN and braced forms, only the canonical form is a valid format.
+		_, err := uuid.Parse(v)
+		return err == nil && len(v) == 36
+	},
+}
+
+// validateFormat validates feature flag for Format, that it is a known format which is only set for
+// string params, and that the default value is valid for it
+func (p ParamSpec) validateFormat(ctx context.Context) *apis.FieldError {
+	errs := config.ValidateEnabledAPIFields(ctx, "format", config.AlphaAPIFields).ViaField(p.Name + ".format")
+	if p.Type != ParamTypeString {
+		return errs.Also(apis.ErrGeneric("format can only be set with string type param", p.Name+".format"))
+	}
+	valid, ok := paramFormats[p.Format]
+	if !ok {
+		return errs.Also(apis.ErrInvalidValue(p.Format, p.Name+".format", fmt.Sprintf("allowed formats are %q", sets.StringKeySet(paramFormats).List())))
+	}
+	if p.Default != nil && p.Default.Type == ParamTypeString && !strings.Contains(p.Default.StringVal, "$(") && !valid(p.Default.StringVal) {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("param default value %q is not a valid %s", p.Default.StringVal, p.Format), p.Name+".default"))
+	}
+	return errs
+}
+
+// matchesPattern returns true if the value matches the pattern, or contains variables which can
+// only be checked once they are replaced
+func matchesPattern(re *regexp.Regexp, value string) bool {
+	return strings.Contains(value, "$(") || re.MatchString(value)
+}
+
 // findDups returns the duplicate element in the given slice
 func findDups(vals []string) sets.String {
 	seen := sets.String{}
@@ -431,10 +569,13 @@ const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
 	ParamTypeObject ParamType = "object"
+	// ParamTypeInteger is a string param whose value must be a whole number.
+	// Its values are stored as strings in ParamValue.
+	ParamTypeInteger ParamType = "integer"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject, ParamTypeInteger}
 
 // ParamValues is modeled after IntOrString in kubernetes/apimachinery:
 
@@ -66,10 +66,20 @@ func TestPipelineConversion(t *testing.T) {
 					}},
 				}},
 				Params: []v1beta1.ParamSpec{{
-					Name:        "param-1",
-					Type:        v1beta1.ParamTypeString,
-					Enum:        []string{"v1", "v2"},
-					Description: "My first param",
+					Name:           "param-1",
+					Type:           v1beta1.ParamTypeString,
+					Enum:           []string{"v1", "v2"},
+					AllowedPattern: "v[0-9]+",
+					Description:    "My first param",
+				}, {
+					Name:     "param-2",
+					Type:     v1beta1.ParamTypeArray,
+					MinItems: 1,
+					MaxItems: 3,
+				}, {
+					Name:   "param-3",
+					Type:   v1beta1.ParamTypeString,
+					Format: "uri",
 				}},
 			},
 		},
@@ -438,6 +438,8 @@ func ValidatePipelineParameterVariables(ctx context.Context, tasks []PipelineTas
 	errs = errs.Also(ValidateParameterTypes(ctx, params).ViaField("params"))
 	errs = errs.Also(params.validateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamPatterns(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamItemCounts(ctx).ViaField("params"))
 	for i, task := range tasks {
 		errs = errs.Also(task.Params.validateDuplicateParameters().ViaField("params").ViaIndex(i))
 	}
@@ -663,11 +663,14 @@ func TestStepActionSpecValidateError(t *testing.T) {
 			}},
 			Image: "myImage",
 		},
-		expectedError: apis.FieldError{
+		expectedError: *(&apis.FieldError{
 			Message: fmt.Sprintf("The format of following array and string variable names is invalid: %s", []string{"", "0ab", "a^b", "f oo"}),
 			Paths:   []string{"params"},
 			Details: "String/Array Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_), and dots (.)\nMust begin with a letter or an underscore (_)",
-		},
+		}).Also(apis.ErrInvalidValue("", "params[].name")).
+			Also(apis.ErrInvalidValue("0ab", "params[0ab].name")).
+			Also(apis.ErrInvalidValue("a^b", "params[a^b].name")).
+			Also(apis.ErrInvalidValue("f oo", "params[f oo].name")),
 	}, {
 		name: "invalid object param format - object param name and key name shouldn't contain dots.",
 		fields: fields{
@@ -681,13 +684,14 @@ func TestStepActionSpecValidateError(t *testing.T) {
 			}},
 			Image: "myImage",
 		},
-		expectedError: apis.FieldError{
+		expectedError: *(&apis.FieldError{
 			Message: fmt.Sprintf("Object param name and key name format is invalid: %v", map[string][]string{
 				"invalid.name1": {"invalid.key1"},
 			}),
 			Paths:   []string{"params"},
 			Details: "Object Names: \nMust only contain alphanumeric characters, hyphens (-), underscores (_) \nMust begin with a letter or an underscore (_)",
-		},
+		}).Also(apis.ErrInvalidValue("invalid.name1", "params[invalid.name1].name")).
+			Also(apis.ErrInvalidValue("invalid.key1", "params[invalid.name1].properties[invalid.key1]")),
 	}, {
 		name: "duplicated param names",
 		fields: fields{
@@ -110,6 +110,19 @@ spec:
        - input: "$(workspaces.custom.bound)"
          operator: in
          values: ["true"]
+`
+	integerParamTaskYAML := `
+metadata:
+  name: foo
+  namespace: bar
+spec:
+  steps:
+  - image: foo
+    args: ["$(params.count)"]
+  params:
+  - name: count
+    type: integer
+    default: "3"
 `
 	stepActionTaskYAML := `
 metadata:
@@ -348,6 +361,9 @@ spec:
 	stepWhenTaskV1beta1 := parse.MustParseV1beta1Task(t, stepWhenTaskYAML)
 	stepWhenTaskV1 := parse.MustParseV1Task(t, stepWhenTaskYAML)
 
+	integerParamTaskV1beta1 := parse.MustParseV1beta1Task(t, integerParamTaskYAML)
+	integerParamTaskV1 := parse.MustParseV1Task(t, integerParamTaskYAML)
+
 	stepActionTaskV1beta1 := parse.MustParseV1beta1Task(t, stepActionTaskYAML)
 	stepActionTaskV1 := parse.MustParseV1Task(t, stepActionTaskYAML)
 
@@ -397,6 +413,10 @@ spec:
 		name:        "step when in task",
 		v1beta1Task: stepWhenTaskV1beta1,
 		v1Task:      stepWhenTaskV1,
+	}, {
+		name:        "integer param in task",
+		v1beta1Task: integerParamTaskV1beta1,
+		v1Task:      integerParamTaskV1,
 	}, {
 		name:        "step action in task",
 		v1beta1Task: stepActionTaskV1beta1,
@@ -19,9 +19,11 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -73,7 +75,8 @@ func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	return errs.Also(validateStepTemplateUsageOfDeclaredParameters(ctx, t.Spec.StepTemplate, t.Spec.Params).ViaField("spec"))
 }
 
 // Validate implements apis.Validatable
@@ -110,14 +113,29 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 // ValidateUsageOfDeclaredParameters validates that all parameters referenced in the Task are declared by the Task.
 func ValidateUsageOfDeclaredParameters(ctx context.Context, steps []Step, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
-	_, _, objectParams := params.sortByType()
 	allParameterNames := sets.NewString(params.getNames()...)
 	errs = errs.Also(validateVariables(ctx, steps, "params", allParameterNames))
-	errs = errs.Also(validateObjectUsage(ctx, steps, objectParams))
+	errs = errs.Also(validateObjectUsage(ctx, steps, params))
 	errs = errs.Also(validateObjectParamsHaveProperties(ctx, params))
 	return errs
 }
 
+// validateStepTemplateUsageOfDeclaredParameters validates that all parameters referenced in the StepTemplate
+// are declared, and that object parameters are not referenced as a whole where this is prohibited.
+func validateStepTemplateUsageOfDeclaredParameters(ctx context.Context, stepTemplate *StepTemplate, params ParamSpecs) (errs *apis.FieldError) {
+	if stepTemplate == nil {
+		return nil
+	}
+	step := stepTemplate.toStep()
+	_, _, objectParams := params.sortByType()
+	errs = errs.Also(validateStepVariables(ctx, step, "params", sets.NewString(params.getNames()...)))
+	for _, p := range objectParams {
+		errs = errs.Also(validateStepVariables(ctx, step, "params\\."+p.Name, sets.StringKeySet(p.Properties)))
+	}
+	errs = errs.Also(validateStepObjectUsageAsWhole(step, "params", sets.NewString(objectParams.getNames()...)))
+	return errs.ViaField("stepTemplate")
+}
+
 // validateObjectParamsHaveProperties returns an error if any declared object params are missing properties
 func validateObjectParamsHaveProperties(ctx context.Context, params ParamSpecs) *apis.FieldError {
 	var errs *apis.FieldError
@@ -519,6 +537,19 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(p.Type, p.Name+".type")
 	}
 
+	if p.Format != "" {
+		if errs := p.validateFormat(ctx); errs != nil {
+			return errs
+		}
+	}
+
+	// Integer params are an alpha feature and will fail validation if they are declared
+	// when the enable-api-fields feature gate is not "alpha".
+	if p.Type == ParamTypeInteger {
+		return config.ValidateEnabledAPIFields(ctx, "integer param type", config.AlphaAPIFields).ViaField(p.Name + ".type").
+			Also(p.validateIntegerDefault())
+	}
+
 	// If a default value is provided, ensure its type matches param's declared type.
 	if (p.Default != nil) && (p.Default.Type != p.Type) {
 		return &apis.FieldError{
@@ -535,6 +566,31 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	return p.ValidateObjectType(ctx)
 }
 
+// validateIntegerDefault checks that the default value of an integer param, if any,
+// is a string holding a whole number.
+func (p ParamSpec) validateIntegerDefault() *apis.FieldError {
+	if p.Default == nil {
+		return nil
+	}
+	if p.Default.Type != ParamTypeString {
+		return &apis.FieldError{
+			Message: fmt.Sprintf(
+				"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
+			Paths: []string{
+				p.Name + ".type",
+				p.Name + ".default.type",
+			},
+		}
+	}
+	if _, err := strconv.ParseInt(p.Default.StringVal, 10, 64); err != nil {
+		if f, ferr := strconv.ParseFloat(p.Default.StringVal, 64); ferr == nil && f != math.Trunc(f) {
+			return apis.ErrGeneric(fmt.Sprintf("default value %q of integer param must not have a fractional part", p.Default.StringVal), p.Name+".default")
+		}
+		return apis.ErrGeneric(fmt.Sprintf("default value %q of integer param is not a valid integer", p.Default.StringVal), p.Name+".default")
+	}
+	return nil
+}
+
 // ValidateObjectType checks that object type parameter does not miss the
 // definition of `properties` section and the type of a PropertySpec is allowed.
 // (Currently, only string is allowed)
@@ -561,6 +617,8 @@ func ValidateParameterVariables(ctx context.Context, steps []Step, params ParamS
 	var errs *apis.FieldError
 	errs = errs.Also(params.validateNoDuplicateNames())
 	errs = errs.Also(params.validateParamEnums(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamPatterns(ctx).ViaField("params"))
+	errs = errs.Also(params.validateParamItemCounts(ctx).ViaField("params"))
 	stringParams, arrayParams, objectParams := params.sortByType()
 	stringParameterNames := sets.NewString(stringParams.getNames()...)
 	arrayParameterNames := sets.NewString(arrayParams.getNames()...)
@@ -595,10 +653,20 @@ func validateTaskResultsVariables(ctx context.Context, steps []Step, results []T
 	return errs
 }
 
-// validateObjectUsage validates the usage of individual attributes of an object param and the usage of the entire object
+// validateObjectUsage validates the usage of individual attributes of an object param and the usage of the entire object.
+// Individual keys can only be referenced on object params, so key references to other params are rejected.
 func validateObjectUsage(ctx context.Context, steps []Step, params []ParamSpec) (errs *apis.FieldError) {
 	objectParameterNames := sets.NewString()
 	for _, p := range params {
+		if p.Type != ParamTypeObject {
+			// only object params have keys, e.g. param.stringParam["key1"] is invalid;
+			// params without a type have not been defaulted yet and are skipped
+			if p.Type != "" {
+				errs = errs.Also(validateVariables(ctx, steps, "params\\."+p.Name, sets.NewString()))
+			}
+			continue
+		}
+
 		// collect all names of object type params
 		objectParameterNames.Insert(p.Name)
 
@@ -667,7 +735,10 @@ func validateStepArrayUsage(step Step, prefix string, arrayParamNames sets.Strin
 		errs = errs.Also(substitution.ValidateVariableReferenceIsIsolated(arg, prefix, arrayParamNames).ViaFieldIndex("args", i))
 	}
 	for _, env := range step.Env {
-		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(env.Value, prefix, arrayParamNames).ViaFieldKey("env", env.Name))
+		// An env value holds a single string, so only indexed references to array params are allowed.
+		if err := substitution.ValidateNoReferencesToProhibitedVariables(env.Value, prefix, arrayParamNames); err != nil {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("env value %q cannot reference an entire array param, reference an individual element with $(%s.<name>[i]) instead", env.Value, prefix), "").ViaFieldKey("env", env.Name))
+		}
 	}
 	for i, v := range step.VolumeMounts {
 		errs = errs.Also(substitution.ValidateNoReferencesToProhibitedVariables(v.Name, prefix, arrayParamNames).ViaField("name").ViaFieldIndex("volumeMount", i))
@@ -82,6 +82,74 @@ func TestTaskValidate(t *testing.T) {
 	}
 }
 
+func TestTaskValidate_StepTemplateParamUsage(t *testing.T) {
+	params := []v1beta1.ParamSpec{{
+		Name: "gitrepo",
+		Type: v1beta1.ParamTypeObject,
+		Properties: map[string]v1beta1.PropertySpec{
+			"url": {Type: v1beta1.ParamTypeString},
+		},
+	}, {
+		Name: "workdir",
+		Type: v1beta1.ParamTypeString,
+	}}
+	tests := []struct {
+		name          string
+		stepTemplate  *v1beta1.StepTemplate
+		expectedError *apis.FieldError
+	}{{
+		name: "params used only in stepTemplate",
+		stepTemplate: &v1beta1.StepTemplate{
+			WorkingDir: "$(params.workdir)",
+			Env: []corev1.EnvVar{{
+				Name:  "URL",
+				Value: "$(params.gitrepo.url)",
+			}},
+		},
+	}, {
+		name: "whole object reference",
+		stepTemplate: &v1beta1.StepTemplate{
+			Args: []string{"--repo=$(params.gitrepo)"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "--repo=$(params.gitrepo)"`,
+			Paths:   []string{"spec.stepTemplate.args[0]"},
+		},
+	}, {
+		name: "undeclared param and object key",
+		stepTemplate: &v1beta1.StepTemplate{
+			Env: []corev1.EnvVar{{
+				Name:  "REVISION",
+				Value: "$(params.revision)",
+			}, {
+				Name:  "BRANCH",
+				Value: "$(params.gitrepo.branch)",
+			}},
+		},
+		expectedError: apis.ErrGeneric(`non-existent variable in "$(params.gitrepo.branch)"`, "spec.stepTemplate.env[BRANCH]").
+			Also(apis.ErrGeneric(`non-existent variable in "$(params.revision)"`, "spec.stepTemplate.env[REVISION]")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &v1beta1.Task{
+				ObjectMeta: metav1.ObjectMeta{Name: "task"},
+				Spec: v1beta1.TaskSpec{
+					Params:       params,
+					StepTemplate: tt.stepTemplate,
+					Steps: []v1beta1.Step{{
+						Name:  "mystep",
+						Image: "myimage",
+					}},
+				},
+			}
+			err := task.Validate(t.Context())
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("Task.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidatePropagatedParamsAndWorkspaces(t *testing.T) {
 	type fields struct {
 		Params       []v1beta1.ParamSpec
@@ -731,6 +799,23 @@ func TestTaskValidateError(t *testing.T) {
 			Message: `non-existent variable in "$(params.gitrepo.non-exist-key)"`,
 			Paths:   []string{"spec.steps[0].args[0]"},
 		},
+	}, {
+		name: "key of a string param is referenced with bracket notation in task step",
+		fields: fields{
+			Params: []v1beta1.ParamSpec{{
+				Name: "foo",
+				Type: v1beta1.ParamTypeString,
+			}},
+			Steps: []v1beta1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{`$(params.foo["key"])`},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "$(params.foo[\"key\"])"`,
+			Paths:   []string{"spec.steps[0].args[0]"},
+		},
 	}, {
 		name: "Inexistent param variable in volumeMount with existing",
 		fields: fields{
@@ -1962,6 +2047,62 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 				}},
 			}},
 		},
+	}, {
+		name:            "integer param requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:    "count",
+				Type:    v1beta1.ParamTypeInteger,
+				Default: v1beta1.NewStructuredValues("3"),
+			}},
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				Args:  []string{"$(params.count)"},
+			}},
+		},
+	}, {
+		name:            "param allowed pattern requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:           "flag",
+				AllowedPattern: "--[a-z-]+",
+			}},
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				Args:  []string{"$(params.flag)"},
+			}},
+		},
+	}, {
+		name:            "param minItems and maxItems require alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:     "flags",
+				Type:     v1beta1.ParamTypeArray,
+				MinItems: 1,
+				MaxItems: 2,
+			}},
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				Args:  []string{"$(params.flags[*])"},
+			}},
+		},
+	}, {
+		name:            "param format requires alpha",
+		requiredVersion: "alpha",
+		spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{
+				Name:   "url",
+				Type:   v1beta1.ParamTypeString,
+				Format: "uri",
+			}},
+			Steps: []v1beta1.Step{{
+				Image: "foo",
+				Args:  []string{"$(params.url)"},
+			}},
+		},
 	}, {
 		name:            "windows script support requires alpha",
 		requiredVersion: "alpha",
@@ -2316,6 +2457,41 @@ func TestParamEnum_Failure(t *testing.T) {
 	}
 }
 
+func TestTaskSpecValidate_StepEnvArrayUsage(t *testing.T) {
+	params := []v1beta1.ParamSpec{{
+		Name: "arr",
+		Type: v1beta1.ParamTypeArray,
+	}}
+	tests := []struct {
+		name          string
+		env           []corev1.EnvVar
+		expectedError *apis.FieldError
+	}{{
+		name: "whole array param star reference in env value",
+		env:  []corev1.EnvVar{{Name: "ARR", Value: "$(params.arr[*])"}},
+		expectedError: &apis.FieldError{
+			Message: `env value "$(params.arr[*])" cannot reference an entire array param, reference an individual element with $(params.<name>[i]) instead`,
+			Paths:   []string{"steps[0].env[ARR]"},
+		},
+	}, {
+		name: "indexed array param in env value",
+		env:  []corev1.EnvVar{{Name: "FIRST", Value: "$(params.arr[0])"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps := []v1beta1.Step{{
+				Name:  "mystep",
+				Image: "myimage",
+				Env:   tt.env,
+			}}
+			err := v1beta1.ValidateParameterVariables(t.Context(), steps, params)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("ValidateParameterVariables() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskSpecValidate_StepResults(t *testing.T) {
 	type fields struct {
 		Image   string
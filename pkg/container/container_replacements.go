@@ -72,6 +72,7 @@ func applyContainerReplacements(c *corev1.Container, stringReplacements map[stri
 		}
 	}
 	c.WorkingDir = substitution.ApplyReplacements(c.WorkingDir, stringReplacements)
+	c.TerminationMessagePath = substitution.ApplyReplacements(c.TerminationMessagePath, stringReplacements)
 
 	// Use ApplyArrayReplacements here, as additional commands may be added via an array parameter.
 	var newCommand []string
@@ -85,4 +86,37 @@ func applyContainerReplacements(c *corev1.Container, stringReplacements map[stri
 		c.VolumeMounts[iv].MountPath = substitution.ApplyReplacements(v.MountPath, stringReplacements)
 		c.VolumeMounts[iv].SubPath = substitution.ApplyReplacements(v.SubPath, stringReplacements)
 	}
+
+	if c.SecurityContext != nil {
+		// The SecurityContext may be shared with the Step or Sidecar the container was built from.
+		c.SecurityContext = c.SecurityContext.DeepCopy()
+		applySecurityContextReplacements(c.SecurityContext, stringReplacements)
+	}
+}
+
+// applySecurityContextReplacements applies variable interpolation on the string fields of a SecurityContext,
+// the other fields cannot hold variable references.
+func applySecurityContextReplacements(sc *corev1.SecurityContext, stringReplacements map[string]string) {
+	replace := func(s *string) {
+		if s != nil {
+			*s = substitution.ApplyReplacements(*s, stringReplacements)
+		}
+	}
+	if o := sc.SELinuxOptions; o != nil {
+		replace(&o.User)
+		replace(&o.Role)
+		replace(&o.Type)
+		replace(&o.Level)
+	}
+	if o := sc.WindowsOptions; o != nil {
+		replace(o.GMSACredentialSpecName)
+		replace(o.GMSACredentialSpec)
+		replace(o.RunAsUserName)
+	}
+	if p := sc.SeccompProfile; p != nil {
+		replace(p.LocalhostProfile)
+	}
+	if p := sc.AppArmorProfile; p != nil {
+		replace(p.LocalhostProfile)
+	}
 }
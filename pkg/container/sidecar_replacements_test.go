@@ -35,12 +35,13 @@ func TestApplySidecarReplacements(t *testing.T) {
 	}
 
 	s := v1.Sidecar{
-		Script:     "$(replace.me)",
-		Name:       "$(replace.me)",
-		Image:      "$(replace.me)",
-		Command:    []string{"$(array.replace.me)"},
-		Args:       []string{"$(array.replace.me)"},
-		WorkingDir: "$(replace.me)",
+		Script:                 "$(replace.me)",
+		Name:                   "$(replace.me)",
+		Image:                  "$(replace.me)",
+		Command:                []string{"$(array.replace.me)"},
+		Args:                   []string{"$(array.replace.me)"},
+		WorkingDir:             "$(replace.me)",
+		TerminationMessagePath: "/dev/$(replace.me)",
 		EnvFrom: []corev1.EnvFromSource{{
 			ConfigMapRef: &corev1.ConfigMapEnvSource{
 				LocalObjectReference: corev1.LocalObjectReference{
@@ -79,12 +80,13 @@ func TestApplySidecarReplacements(t *testing.T) {
 	}
 
 	expected := v1.Sidecar{
-		Script:     "replaced!",
-		Name:       "replaced!",
-		Image:      "replaced!",
-		Command:    []string{"val1", "val2"},
-		Args:       []string{"val1", "val2"},
-		WorkingDir: "replaced!",
+		Script:                 "replaced!",
+		Name:                   "replaced!",
+		Image:                  "replaced!",
+		Command:                []string{"val1", "val2"},
+		Args:                   []string{"val1", "val2"},
+		WorkingDir:             "replaced!",
+		TerminationMessagePath: "/dev/replaced!",
 		EnvFrom: []corev1.EnvFromSource{{
 			ConfigMapRef: &corev1.ConfigMapEnvSource{
 				LocalObjectReference: corev1.LocalObjectReference{
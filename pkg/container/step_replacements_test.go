@@ -24,6 +24,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/container"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/utils/ptr"
 )
 
 func TestApplyStepReplacements(t *testing.T) {
@@ -85,6 +86,10 @@ func TestApplyStepReplacements(t *testing.T) {
 			MountPath: "$(replace.me)",
 			SubPath:   "$(replace.me)",
 		}},
+		SecurityContext: &corev1.SecurityContext{
+			SELinuxOptions: &corev1.SELinuxOptions{User: "$(replace.me)"},
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: ptr.To("$(replace.me)")},
+		},
 		StdoutConfig: &v1.StepOutputConfig{
 			Path: "$(workspaces.data.path)/stdout.txt",
 		},
@@ -142,6 +147,10 @@ func TestApplyStepReplacements(t *testing.T) {
 			MountPath: "replaced!",
 			SubPath:   "replaced!",
 		}},
+		SecurityContext: &corev1.SecurityContext{
+			SELinuxOptions: &corev1.SELinuxOptions{User: "replaced!"},
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: ptr.To("replaced!")},
+		},
 		StdoutConfig: &v1.StepOutputConfig{
 			Path: "/workspace/data/stdout.txt",
 		},
@@ -368,7 +368,7 @@ func StepName(name string, i int) string {
 	if name != "" {
 		return GetContainerName(name)
 	}
-	return fmt.Sprintf("%sunnamed-%d", stepPrefix, i)
+	return fmt.Sprintf("%s%s%d", stepPrefix, pipeline.ReservedStepNamePrefix, i)
 }
 
 // GetContainerName prefixes the input name with "step-"
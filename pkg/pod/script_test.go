@@ -17,6 +17,8 @@ limitations under the License.
 package pod
 
 import (
+	"encoding/base64"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -194,6 +196,31 @@ _EOF_
 	}
 }
 
+func TestConvertScripts_DoubleDollarPreserved(t *testing.T) {
+	names.TestingSeed()
+	script := `#!/bin/sh
+cat > Makefile <<'MAKEFILE'
+all:
+	echo $$(shell pwd)
+MAKEFILE
+make`
+	gotInit, _, _ := convertScripts(images.ShellImage, images.ShellImageWin, []v1.Step{{
+		Script: script,
+		Image:  "step-1",
+	}}, nil, nil, SecurityContextConfig{})
+	lines := strings.Split(gotInit.Args[1], "\n")
+	if len(lines) < 4 {
+		t.Fatalf("unexpected place-scripts args: %q", gotInit.Args[1])
+	}
+	got, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil {
+		t.Fatalf("decoding the script: %v", err)
+	}
+	if d := cmp.Diff(script, string(got)); d != "" {
+		t.Errorf("Script Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestConvertScripts_Sidecars(t *testing.T) {
 	names.TestingSeed()
 
@@ -497,7 +497,8 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1.PipelineRun, getPipel
 		return controller.NewPermanentError(err)
 	}
 
-	if err := pipelineSpec.Validate(ctx); err != nil {
+	// Validation warnings are surfaced at admission and do not prevent the Pipeline from running.
+	if err := pipelineSpec.Validate(ctx).Filter(apis.ErrorLevel); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
 		pr.Status.MarkFailed(v1.PipelineRunReasonFailedValidation.String(),
 			"Pipeline %s/%s can't be Run; it has an invalid spec: %s",
@@ -18,6 +18,7 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -44,6 +45,12 @@ const (
 )
 
 var (
+	// objectIndividualVariablePatterns are the reference patterns for object individual keys, using dot or bracket notation
+	objectIndividualVariablePatterns = []string{
+		objectIndividualVariablePattern,
+		"params.%s[%q]",
+	}
+
 	paramPatterns = []string{
 		"params.%s",
 		"params[%q]",
@@ -410,12 +417,15 @@ func replacementsFromDefaultParams(defaults v1.ParamSpecs) (map[string]string, m
 					}
 					arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.ArrayVal
 				}
+				arrayElementKeyReplacements(stringReplacements, p.Name, p.Default.ArrayVal)
 			case v1.ParamTypeObject:
 				for _, pattern := range paramPatterns {
 					objectReplacements[fmt.Sprintf(pattern, p.Name)] = p.Default.ObjectVal
 				}
 				for k, v := range p.Default.ObjectVal {
-					stringReplacements[fmt.Sprintf(objectIndividualVariablePattern, p.Name, k)] = v
+					for _, pattern := range objectIndividualVariablePatterns {
+						stringReplacements[fmt.Sprintf(pattern, p.Name, k)] = v
+					}
 				}
 			case v1.ParamTypeString:
 				fallthrough
@@ -455,6 +465,20 @@ func replacementsFromDefaultParams(defaults v1.ParamSpecs) (map[string]string, m
 	return stringReplacements, arrayReplacements, objectReplacements
 }
 
+// arrayElementKeyReplacements adds the replacements of the keys of the elements of an array param which
+// are JSON objects, as the elements of an array of objects param are, e.g. $(params.items[0].name).
+func arrayElementKeyReplacements(stringReplacements map[string]string, name string, elements []string) {
+	for i, e := range elements {
+		var m map[string]string
+		if !strings.HasPrefix(strings.TrimSpace(e), "{") || json.Unmarshal([]byte(e), &m) != nil {
+			continue
+		}
+		for k, v := range m {
+			stringReplacements[fmt.Sprintf("params.%s[%d].%s", name, i, k)] = v
+		}
+	}
+}
+
 func replacementsFromParams(params v1.Params) (map[string]string, map[string][]string, map[string]map[string]string) {
 	// stringReplacements is used for standard single-string stringReplacements, while arrayReplacements contains arrays
 	// and objectReplacements contains objects that need to be further processed.
@@ -471,12 +495,15 @@ func replacementsFromParams(params v1.Params) (map[string]string, map[string][]s
 				}
 				arrayReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.ArrayVal
 			}
+			arrayElementKeyReplacements(stringReplacements, p.Name, p.Value.ArrayVal)
 		case v1.ParamTypeObject:
 			for _, pattern := range paramPatterns {
 				objectReplacements[fmt.Sprintf(pattern, p.Name)] = p.Value.ObjectVal
 			}
 			for k, v := range p.Value.ObjectVal {
-				stringReplacements[fmt.Sprintf(objectIndividualVariablePattern, p.Name, k)] = v
+				for _, pattern := range objectIndividualVariablePatterns {
+					stringReplacements[fmt.Sprintf(pattern, p.Name, k)] = v
+				}
 			}
 		case v1.ParamTypeString:
 			fallthrough
@@ -613,6 +640,21 @@ func getStepResultReplacements(step v1.Step, idx int) map[string]string {
 	return stringReplacements
 }
 
+// ApplyEscapedReferences reduces the escaped references to Tekton variables in every field of the TaskSpec,
+// e.g. "$$(params.foo)", to the references they stand for, e.g. "$(params.foo)". It is applied once all the
+// variables are substituted, so that the references it leaves are not substituted anymore.
+func ApplyEscapedReferences(spec *v1.TaskSpec) *v1.TaskSpec {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return spec
+	}
+	unescaped := &v1.TaskSpec{}
+	if err := json.Unmarshal([]byte(substitution.UnescapeReferences(string(b))), unescaped); err != nil {
+		return spec
+	}
+	return unescaped
+}
+
 // getTaskResultReplacements creates all combinations of string replacements from TaskResults.
 func getTaskResultReplacements(spec *v1.TaskSpec) map[string]string {
 	stringReplacements := map[string]string{}
@@ -669,9 +711,24 @@ func ApplyCredentialsPath(spec *v1.TaskSpec, path string) *v1.TaskSpec {
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{}, map[string]map[string]string{})
 }
 
+// objectJSONReplacements returns replacements which expand whole object references using the [*] notation,
+// e.g. $(params.myObject[*]), into the JSON encoding of the object.
+func objectJSONReplacements(objectReplacements map[string]map[string]string) map[string]string {
+	replacements := map[string]string{}
+	for k, v := range objectReplacements {
+		b, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		replacements[k+"[*]"] = string(b)
+	}
+	return replacements
+}
+
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
 func ApplyReplacements(spec *v1.TaskSpec, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1.TaskSpec {
 	spec = spec.DeepCopy()
+	scriptObjectReplacements := objectJSONReplacements(objectReplacements)
 
 	// Apply variable expansion to steps fields.
 	steps := spec.Steps
@@ -680,6 +737,7 @@ func ApplyReplacements(spec *v1.TaskSpec, stringReplacements map[string]string,
 			steps[i].Params = steps[i].Params.ReplaceVariables(stringReplacements, arrayReplacements, objectReplacements)
 		}
 		container.ApplyStepReplacements(&steps[i], stringReplacements, arrayReplacements)
+		steps[i].Script = substitution.ApplyReplacements(steps[i].Script, scriptObjectReplacements)
 	}
 
 	// Apply variable expansion to stepTemplate fields.
@@ -972,6 +972,77 @@ func TestApplyObjectParameters(t *testing.T) {
 	}
 }
 
+func TestApplyObjectParameters_ScriptJSONExpansion(t *testing.T) {
+	tr := &v1.TaskRun{
+		Spec: v1.TaskRunSpec{
+			Params: []v1.Param{{
+				Name: "myObject",
+				Value: *v1.NewObject(map[string]string{
+					"key1": "value1",
+					"key2": "value2",
+				}),
+			}},
+		},
+	}
+	spec := &v1.TaskSpec{
+		Steps: []v1.Step{{
+			Name:   "print",
+			Image:  "bash",
+			Script: "echo '$(params.myObject[*])'\necho '$(params[\"myObject\"][*])'\necho $(params.myObject.key1)",
+		}},
+	}
+	want := applyMutation(spec, func(spec *v1.TaskSpec) {
+		spec.Steps[0].Script = "echo '{\"key1\":\"value1\",\"key2\":\"value2\"}'\necho '{\"key1\":\"value1\",\"key2\":\"value2\"}'\necho value1"
+	})
+	got := resources.ApplyParameters(spec, tr)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyParameters_ArrayOfObjects(t *testing.T) {
+	spec := &v1.TaskSpec{
+		Params: []v1.ParamSpec{{
+			Name:       "items",
+			Type:       v1.ParamTypeArray,
+			Properties: map[string]v1.PropertySpec{"name": {Type: v1.ParamTypeString}, "value": {Type: v1.ParamTypeString}},
+			Default:    v1.NewStructuredValues(`{"name":"a","value":"1"}`, `{"name":"b","value":"2"}`),
+		}},
+		Steps: []v1.Step{{
+			Name:  "print",
+			Image: "bash",
+			Args:  []string{"$(params.items[0].name)=$(params.items[0].value)", "$(params.items[1].name)=$(params.items[1].value)"},
+		}},
+	}
+	for _, tc := range []struct {
+		name string
+		tr   *v1.TaskRun
+		want []string
+	}{{
+		name: "default elements",
+		tr:   &v1.TaskRun{},
+		want: []string{"a=1", "b=2"},
+	}, {
+		name: "elements from the TaskRun",
+		tr: &v1.TaskRun{
+			Spec: v1.TaskRunSpec{
+				Params: []v1.Param{{
+					Name:  "items",
+					Value: *v1.NewStructuredValues(`{"name":"c","value":"3"}`, `{"name":"d","value":"4"}`),
+				}},
+			},
+		},
+		want: []string{"c=3", "d=4"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := resources.ApplyParameters(spec, tc.tr, spec.Params...)
+			if d := cmp.Diff(tc.want, got.Steps[0].Args); d != "" {
+				t.Errorf("ApplyParameters() got diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyStepParameters(t *testing.T) {
 	// define the taskrun to test values provided by taskrun can overwrite the values provided in spec's default
 	tr := &v1.TaskRun{
@@ -1641,6 +1712,61 @@ func TestApplyCredentialsPath(t *testing.T) {
 	}
 }
 
+func TestApplyEscapedReferences(t *testing.T) {
+	spec := &v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:    "foo",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("bar"),
+		}},
+		Steps: []v1.Step{{
+			Name:       "step",
+			Image:      "image",
+			WorkingDir: "/workspace/$$(params.dir)",
+			Command:    []string{"echo", "$$(params.foo)"},
+			Args:       []string{"$$(shell pwd)"},
+			Env: []corev1.EnvVar{{
+				Name:  "TASKRUN",
+				Value: "$$(context.taskRun.name)",
+			}},
+			Script: "#!/bin/sh\necho $$(results.out.path) $$(date)",
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "sidecar",
+			Image: "image",
+			Args:  []string{"$$(workspaces.ws.path)"},
+		}},
+	}
+	want := &v1.TaskSpec{
+		Params: v1.ParamSpecs{{
+			Name:    "foo",
+			Type:    v1.ParamTypeString,
+			Default: v1.NewStructuredValues("bar"),
+		}},
+		Steps: []v1.Step{{
+			Name:       "step",
+			Image:      "image",
+			WorkingDir: "/workspace/$(params.dir)",
+			Command:    []string{"echo", "$(params.foo)"},
+			Args:       []string{"$$(shell pwd)"},
+			Env: []corev1.EnvVar{{
+				Name:  "TASKRUN",
+				Value: "$(context.taskRun.name)",
+			}},
+			Script: "#!/bin/sh\necho $(results.out.path) $$(date)",
+		}},
+		Sidecars: []v1.Sidecar{{
+			Name:  "sidecar",
+			Image: "image",
+			Args:  []string{"$(workspaces.ws.path)"},
+		}},
+	}
+	got := resources.ApplyEscapedReferences(spec)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyParametersToWorkspaceBindings(t *testing.T) {
 	tests := []struct {
 		name string
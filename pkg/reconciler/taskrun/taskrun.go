@@ -874,7 +874,8 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 		logger.Errorf("Failed to create a pod for taskrun: %s due to task validation error %v", tr.Name, validateErr)
 		return nil, validateErr
 	}
-	if validateErr := ts.Validate(ctx); validateErr != nil {
+	// Validation warnings are surfaced at admission and do not prevent the Task from running.
+	if validateErr := ts.Validate(ctx).Filter(apis.ErrorLevel); validateErr != nil {
 		logger.Errorf("Failed to create a pod for taskrun: %s due to task validation error %v", tr.Name, validateErr)
 		return nil, validateErr
 	}
@@ -889,6 +890,9 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1.TaskSpec, tr *v1.Task
 	// Apply path substitutions for the legacy credentials helper (aka "creds-init")
 	ts = resources.ApplyCredentialsPath(ts, pipeline.CredsDir)
 
+	// The escaped references are reduced once every variable is substituted.
+	ts = resources.ApplyEscapedReferences(ts)
+
 	podbuilder := podconvert.Builder{
 		Images:          c.Images,
 		KubeClient:      c.KubeClientSet,
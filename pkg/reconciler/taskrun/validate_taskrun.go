@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"errors"
@@ -49,6 +50,9 @@ func validateParams(ctx context.Context, paramSpecs []v1.ParamSpec, params v1.Pa
 	if wrongTypeParamNames := wrongTypeParamsNames(params, matrixParams, neededParamsTypes); len(wrongTypeParamNames) != 0 {
 		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParamNames)
 	}
+	if nonIntegerParamNames := nonIntegerParamsNames(params, matrixParams, neededParamsTypes); len(nonIntegerParamNames) != 0 {
+		return fmt.Errorf("values of these integer params are not valid integers: %s", nonIntegerParamNames)
+	}
 	if missingKeysObjectParamNames := MissingKeysObjectParamNames(paramSpecs, params); len(missingKeysObjectParamNames) != 0 {
 		return fmt.Errorf("missing keys for these params which are required in ParamSpec's properties %v", missingKeysObjectParamNames)
 	}
@@ -95,7 +99,12 @@ func wrongTypeParamsNames(params []v1.Param, matrix v1.Params, neededParamsTypes
 		if param.Value.Type == v1.ParamTypeString && (neededParamsTypes[param.Name] == v1.ParamTypeArray || neededParamsTypes[param.Name] == v1.ParamTypeObject) && v1.VariableSubstitutionRegex.MatchString(param.Value.StringVal) {
 			continue
 		}
-		if param.Value.Type != neededParamsTypes[param.Name] {
+		neededType := neededParamsTypes[param.Name]
+		// Integer params are stored as strings.
+		if neededType == v1.ParamTypeInteger {
+			neededType = v1.ParamTypeString
+		}
+		if param.Value.Type != neededType {
 			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 		}
 	}
@@ -106,13 +115,50 @@ func wrongTypeParamsNames(params []v1.Param, matrix v1.Params, neededParamsTypes
 			continue
 		}
 		// Matrix param replacements must be of type String
-		if neededParamsTypes[param.Name] != v1.ParamTypeString {
+		if neededParamsTypes[param.Name] != v1.ParamTypeString && neededParamsTypes[param.Name] != v1.ParamTypeInteger {
 			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 		}
 	}
 	return wrongTypeParamNames
 }
 
+// nonIntegerParamsNames returns the names of the integer params whose provided values, or matrix
+// values, are not whole numbers. Values that are still to be substituted are not checked.
+func nonIntegerParamsNames(params []v1.Param, matrix v1.Params, neededParamsTypes map[string]v1.ParamType) []string {
+	isInteger := func(value string) bool {
+		if v1.VariableSubstitutionRegex.MatchString(value) {
+			return true
+		}
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	}
+	var nonIntegerParamNames []string
+	for _, param := range params {
+		if neededParamsTypes[param.Name] != v1.ParamTypeInteger || param.Value.Type != v1.ParamTypeString {
+			continue
+		}
+		if !isInteger(param.Value.StringVal) {
+			nonIntegerParamNames = append(nonIntegerParamNames, param.Name)
+		}
+	}
+	for _, param := range matrix {
+		if neededParamsTypes[param.Name] != v1.ParamTypeInteger {
+			continue
+		}
+		values := param.Value.ArrayVal
+		if param.Value.Type == v1.ParamTypeString {
+			values = []string{param.Value.StringVal}
+		}
+		for _, value := range values {
+			if !isInteger(value) {
+				nonIntegerParamNames = append(nonIntegerParamNames, param.Name)
+				break
+			}
+		}
+	}
+	return nonIntegerParamNames
+}
+
 // MissingKeysObjectParamNames checks if all required keys of object type param definitions are provided in params or param definitions' defaults.
 func MissingKeysObjectParamNames(paramSpecs []v1.ParamSpec, params v1.Params) map[string][]string {
 	neededKeys := make(map[string][]string)
@@ -56,6 +56,15 @@ func TestValidateResolvedTask_ValidParams(t *testing.T) {
 				}, {
 					Name: "arrayResultRef",
 					Type: v1.ParamTypeArray,
+				}, {
+					Name: "count",
+					Type: v1.ParamTypeInteger,
+				}, {
+					Name: "countRef",
+					Type: v1.ParamTypeInteger,
+				}, {
+					Name: "matrixCount",
+					Type: v1.ParamTypeInteger,
 				}, {
 					Name: "myObjWithoutDefault",
 					Type: v1.ParamTypeObject,
@@ -95,6 +104,12 @@ func TestValidateResolvedTask_ValidParams(t *testing.T) {
 	}, {
 		Name:  "arrayResultRef",
 		Value: *v1.NewStructuredValues("$(results.resultname[*])"),
+	}, {
+		Name:  "count",
+		Value: *v1.NewStructuredValues("-3"),
+	}, {
+		Name:  "countRef",
+		Value: *v1.NewStructuredValues("$(tasks.counter.results.count)"),
 	}, {
 		Name: "myObjWithoutDefault",
 		Value: *v1.NewObject(map[string]string{
@@ -115,6 +130,9 @@ func TestValidateResolvedTask_ValidParams(t *testing.T) {
 			Value: *v1.NewStructuredValues("a", "b", "c"),
 		}, {
 			Name: "matrixParam", Value: v1.ParamValue{Type: v1.ParamTypeArray, ArrayVal: []string{}},
+		}, {
+			Name:  "matrixCount",
+			Value: *v1.NewStructuredValues("1", "2"),
 		}},
 		Include: []v1.IncludeParams{{
 			Name: "build-1",
@@ -429,6 +447,37 @@ func TestValidateResolvedTask_InvalidParams(t *testing.T) {
 		}},
 		matrix:  &v1.Matrix{},
 		wantErr: "invalid input params for task : missing keys for these params which are required in ParamSpec's properties map[myObjWithoutDefault:[key2]]",
+	}, {
+		name: "non-integer values of integer params",
+		task: v1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: v1.TaskSpec{
+				Params: []v1.ParamSpec{{
+					Name: "fractional",
+					Type: v1.ParamTypeInteger,
+				}, {
+					Name: "word",
+					Type: v1.ParamTypeInteger,
+				}, {
+					Name: "matrixCount",
+					Type: v1.ParamTypeInteger,
+				}},
+			},
+		},
+		params: v1.Params{{
+			Name:  "fractional",
+			Value: *v1.NewStructuredValues("3.5"),
+		}, {
+			Name:  "word",
+			Value: *v1.NewStructuredValues("three"),
+		}},
+		matrix: &v1.Matrix{
+			Params: v1.Params{{
+				Name:  "matrixCount",
+				Value: *v1.NewStructuredValues("1", "two"),
+			}},
+		},
+		wantErr: "invalid input params for task : values of these integer params are not valid integers: [fractional word matrixCount]",
 	}}
 	for _, tc := range tcs {
 		rtr := &resources.ResolvedTask{
@@ -25,10 +25,13 @@ import (
 // based on the mapping provided in replacements.
 // For example, if the input string is "foo: $(params.foo)", and replacements maps "params.foo" to "bar",
 // the output would be "foo: bar".
+// Escaped references, e.g. "$$(params.foo)", are left as they are.
 func ApplyReplacements(in string, replacements map[string]string) string {
 	replacementsList := []string{}
 	for k, v := range replacements {
-		replacementsList = append(replacementsList, fmt.Sprintf("$(%s)", k), v)
+		// The escaped reference starts before the reference it contains, so the replacer matches it first.
+		escaped := fmt.Sprintf("$$(%s)", k)
+		replacementsList = append(replacementsList, escaped, escaped, fmt.Sprintf("$(%s)", k), v)
 	}
 	// strings.Replacer does all replacements in one pass, preventing multiple replacements
 	// See #2093 for an explanation on why we need to do this.
@@ -36,6 +39,26 @@ func ApplyReplacements(in string, replacements map[string]string) string {
 	return replacer.Replace(in)
 }
 
+// escapableReferencePrefixes are the prefixes of the Tekton variables whose references may be escaped,
+// e.g. "$$(params.foo)".
+var escapableReferencePrefixes = []string{"params.", "params[", "context.", "workspaces.", "results.", "steps."}
+
+// escapedReferenceReplacer reduces the escaped references to Tekton variables to the references they stand for.
+var escapedReferenceReplacer = func() *strings.Replacer {
+	oldnew := []string{}
+	for _, prefix := range escapableReferencePrefixes {
+		oldnew = append(oldnew, escapedReference+prefix, "$("+prefix)
+	}
+	return strings.NewReplacer(oldnew...)
+}()
+
+// UnescapeReferences returns a string with the escaped references to Tekton variables, e.g. "$$(params.foo)",
+// reduced to the references they stand for, e.g. "$(params.foo)". It is meant to be applied once all the
+// variables are substituted. Any other "$$(", e.g. "$$(shell pwd)" in a Makefile, is left as it is.
+func UnescapeReferences(in string) string {
+	return escapedReferenceReplacer.Replace(in)
+}
+
 // ApplyArrayReplacements takes an input string, and output an array of strings related to possible arrayReplacements. If there aren't any
 // areas where the input can be split up via arrayReplacements, then just return an array with a single element,
 // which is ApplyReplacements(in, replacements).
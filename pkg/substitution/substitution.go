@@ -41,6 +41,18 @@ const (
 	intIndex = `\[[0-9]+\]`
 )
 
+// escapedReference is the escape of the opening of a variable reference, "$$(" stands for a literal "$(".
+const escapedReference = "$$("
+
+// withoutEscapedReferences returns s with the escaped references, e.g. "$$(params.foo)", altered so
+// that they are not matched as variable references. The length of s is preserved.
+func withoutEscapedReferences(s string) string {
+	return strings.ReplaceAll(s, escapedReference, "$ (")
+}
+
+// objectKeyBracketRegex is used to match an object key referenced with bracket notation, e.g. `obj["key"]`
+var objectKeyBracketRegex = regexp.MustCompile(`^([^.\[]+)\["[^"]*"\]$`)
+
 // arrayIndexingRegex is used to match `[int]` and `[*]`
 var arrayIndexingRegex = regexp.MustCompile(arrayIndexing)
 
@@ -152,6 +164,62 @@ func ValidateNoReferencesToEntireProhibitedVariables(value, prefix string, vars
 	return nil
 }
 
+// ValidateEntireVariablesUseStarNotation returns an error if the input string contains whole references to any
+// variables in vars that do not use the `[*]` notation, e.g. "$(params.foo)" instead of "$(params.foo[*])".
+// References to array indexes or object keys are permitted.
+//
+// Inputs:
+// - value: a string containing a reference to a variable that can be substituted, e.g. "echo $(params.foo[*])"
+// - prefix: the prefix of the substitutable variable, e.g. "params" or "context.pipeline"
+// - vars: names of known variables
+func ValidateEntireVariablesUseStarNotation(value, prefix string, vars sets.String) *apis.FieldError {
+	paths := []string{""} // Empty path is required to make the `ViaField`, … work
+	vs, err := extractEntireVariablesFromString(value, prefix)
+	if err != nil {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("extractEntireVariablesFromString failed : %v", err),
+			Paths:   paths,
+		}
+	}
+
+	for _, v := range vs {
+		if !strings.HasSuffix(v, "[*]") && vars.Has(v) {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("variable %q must be referenced as \"$(%s.%s[*])\" to be expanded as JSON in %q", v, prefix, v, value),
+				Paths:   paths,
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateArrayIndexes returns an error if the input string references an element of one of the
+// given array variables with an index that is neither a non-negative integer nor `*`, e.g. "$(params.foo[-1])".
+// Inputs:
+// - value: a string containing a reference to a variable that can be substituted, e.g. "echo $(params.foo[1])"
+// - prefix: the prefix of the substitutable variable, e.g. "params"
+// - vars: names of known array variables
+func ValidateArrayIndexes(value, prefix string, vars sets.String) *apis.FieldError {
+	re, err := regexp.Compile(fmt.Sprintf(`\$\(%s\.([_a-zA-Z0-9.-]+)\[([^\[\]]*)\]\)`, prefix))
+	if err != nil {
+		return nil
+	}
+	for _, match := range re.FindAllStringSubmatch(withoutEscapedReferences(value), -1) {
+		name, index := match[1], match[2]
+		if !vars.Has(name) || index == "*" {
+			continue
+		}
+		if _, err := strconv.ParseUint(index, 10, 0); err != nil {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("invalid index %q in %q, array elements must be referenced with a non-negative integer", index, match[0]),
+				Paths:   []string{""},
+			}
+		}
+	}
+	return nil
+}
+
 // ValidateVariableReferenceIsIsolated returns an error if the input string contains characters in addition to references to known parameters.
 // For example, if "foo" is a known parameter, a value of "foo: $(params.foo)" returns an error, but a value of "$(params.foo)" does not.
 // Inputs:
@@ -220,7 +288,7 @@ func extractExpressionFromString(s, prefix string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	match := re.FindStringSubmatch(s)
+	match := re.FindStringSubmatch(withoutEscapedReferences(s))
 	if match == nil {
 		return "", nil
 	}
@@ -231,13 +299,14 @@ func extractExpressionFromString(s, prefix string) (string, error) {
 // It returns a slice of strings which contains the extracted variables, a bool flag to indicate if matches were found
 // and the error string if the referencing of parameters is invalid.
 // If the string does not contain the input prefix then the output will contain a slice of strings with length 0.
+// Escaped references, e.g. "$$(params.foo)", are not extracted.
 func ExtractVariablesFromString(s, prefix string) ([]string, bool, string) {
 	pattern := fmt.Sprintf(braceMatchingRegex, prefix, parameterSubstitution, parameterSubstitution, parameterSubstitution)
 	re, err := regexp.Compile(pattern)
 	if err != nil {
 		return nil, false, ""
 	}
-	matches := re.FindAllStringSubmatch(s, -1)
+	matches := re.FindAllStringSubmatch(withoutEscapedReferences(s), -1)
 	errString := ""
 	// Input string does not contain the prefix and therefore not matches are found.
 	if len(matches) == 0 {
@@ -254,6 +323,14 @@ func ExtractVariablesFromString(s, prefix string) ([]string, bool, string) {
 			//  - extract "anObject" from <prefix>.anObject.key
 			// Invalid Examples:
 			//  - <prefix>.foo.bar.baz....
+			// An object key may also be referenced with bracket notation, e.g. <prefix>.anObject["a key"],
+			// in which case only the object name is extracted.
+			if j == 0 {
+				if m := objectKeyBracketRegex.FindStringSubmatch(val); m != nil {
+					vars[i] = m[1]
+					break
+				}
+			}
 			if j == 0 && strings.Contains(val, ".") {
 				if len(strings.Split(val, ".")) > 2 {
 					errString = fmt.Sprintf(`Invalid referencing of parameters in "%s"! Only two dot-separated components after the prefix "%s" are allowed.`, s, prefix)
@@ -279,7 +356,7 @@ func extractEntireVariablesFromString(s, prefix string) ([]string, error) {
 		return nil, fmt.Errorf("failed to parse regex pattern: %w", err)
 	}
 
-	matches := re.FindAllStringSubmatch(s, -1)
+	matches := re.FindAllStringSubmatch(withoutEscapedReferences(s), -1)
 	if len(matches) == 0 {
 		return []string{}, nil
 	}
@@ -325,7 +402,7 @@ func ExtractVariableExpressions(s, prefix string) ([]string, error) {
 		return nil, fmt.Errorf("failed to parse regex pattern: %w", err)
 	}
 
-	matches := re.FindAllString(s, -1)
+	matches := re.FindAllString(withoutEscapedReferences(s), -1)
 	if len(matches) == 0 {
 		return []string{}, nil
 	}
@@ -178,6 +178,25 @@ func TestValidateNoReferencesToUnknownVariables(t *testing.T) {
 			Message: `non-existent variable in "--flag=$(params.objectParam.key3)"`,
 			Paths:   []string{""},
 		},
+	}, {
+		name: "escaped reference to undefined variable",
+		args: args{
+			input:  "echo $$(params.notvar)",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		expectedError: nil,
+	}, {
+		name: "undefined variable next to an escaped reference",
+		args: args{
+			input:  "echo $$(params.notvar) $(params.real)",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "echo $$(params.notvar) $(params.real)"`,
+			Paths:   []string{""},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			got := substitution.ValidateNoReferencesToUnknownVariables(tc.args.input, tc.args.prefix, tc.args.vars)
@@ -446,6 +465,92 @@ func TestValidateVariableReferenceIsIsolated(t *testing.T) {
 	}
 }
 
+func TestValidateArrayIndexes(t *testing.T) {
+	type args struct {
+		input  string
+		prefix string
+		vars   sets.String
+	}
+	for _, tc := range []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{{
+		name: "non-negative index",
+		args: args{
+			input:  "--flag=$(params.foo[0])",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		wantErr: false,
+	}, {
+		name: "entire array",
+		args: args{
+			input:  "$(params.foo[*])",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		wantErr: false,
+	}, {
+		name: "negative index",
+		args: args{
+			input:  "$(params.foo[-1])",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		wantErr: true,
+	}, {
+		name: "non-integer index",
+		args: args{
+			input:  "echo $(params.foo[abc])",
+			prefix: "params",
+			vars:   sets.NewString("foo"),
+		},
+		wantErr: true,
+	}, {
+		name: "unknown variable",
+		args: args{
+			input:  "$(params.foo[-1])",
+			prefix: "params",
+			vars:   sets.NewString("bar"),
+		},
+		wantErr: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := substitution.ValidateArrayIndexes(tc.args.input, tc.args.prefix, tc.args.vars)
+			if (got != nil) != tc.wantErr {
+				t.Errorf("wantErr was %t but got err %s", tc.wantErr, got)
+			}
+		})
+	}
+}
+
+func TestUnescapeReferences(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		input string
+		want  string
+	}{{
+		name:  "escaped references to Tekton variables",
+		input: `echo $$(params.foo) $$(params["foo"]) $$(context.taskRun.name) $$(workspaces.ws.path) $$(results.out.path) $$(steps.step-a.exitCode.path)`,
+		want:  `echo $(params.foo) $(params["foo"]) $(context.taskRun.name) $(workspaces.ws.path) $(results.out.path) $(steps.step-a.exitCode.path)`,
+	}, {
+		name:  "unrelated double dollars",
+		input: "echo $$(shell pwd) $$(date) $$HOME",
+		want:  "echo $$(shell pwd) $$(date) $$HOME",
+	}, {
+		name:  "unescaped references",
+		input: "echo $(params.foo)",
+		want:  "echo $(params.foo)",
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			if d := cmp.Diff(tt.want, substitution.UnescapeReferences(tt.input)); d != "" {
+				t.Errorf("UnescapeReferences() diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestApplyReplacements(t *testing.T) {
 	type args struct {
 		input        string
@@ -496,6 +601,14 @@ func TestApplyReplacements(t *testing.T) {
 			},
 			expectedOutput: "this is a string",
 		},
+		{
+			name: "escaped reference not replaced",
+			args: args{
+				input:        "this $$(is) a $(string)",
+				replacements: map[string]string{"is": "foo", "string": "sstring"},
+			},
+			expectedOutput: "this $$(is) a sstring",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -778,6 +891,20 @@ func TestExtractVariablesFromString(t *testing.T) {
 		want:      []string{},
 		extracted: false,
 		err:       "",
+	}, {
+		name:      "object key with double quote bracket",
+		s:         `--flag=$(params.obj["a key"])`,
+		prefix:    "params",
+		want:      []string{"obj"},
+		extracted: true,
+		err:       "",
+	}, {
+		name:      "object key with single quote bracket is not an object key reference",
+		s:         `--flag=$(params.obj['key'])`,
+		prefix:    "params",
+		want:      []string{"obj['key']"},
+		extracted: true,
+		err:       "",
 	}, {
 		name:      "too many dots",
 		s:         "--flag=$(inputs.params.foo.baz.bar)",